@@ -0,0 +1,172 @@
+package command
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/emits-io/emits/configuration"
+)
+
+const (
+	// configFlag global argument naming the configuration file
+	configFlag = "config"
+	// configEnvironment variable naming the configuration file when the config argument is not given
+	configEnvironment = "EMITS_CONFIG"
+)
+
+// working directory the command was invoked from
+var working string
+
+// Configure removes the global config argument from the command line, locates the configuration file and changes the working directory to the directory of the configuration file so task patterns and output paths resolve relative to it.
+//
+// The config argument takes precedence over the EMITS_CONFIG environment variable; otherwise the nearest emits.json, emits.yaml, emits.yml or emits.toml walking up from the working directory is used.
+func Configure() (err error) {
+	working, err = os.Getwd()
+	if err != nil {
+		return err
+	}
+	file := os.Getenv(configEnvironment)
+	var arguments []string
+	for i := 0; i < len(os.Args); i++ {
+		argument := strings.TrimLeft(os.Args[i], "-")
+		if i == 0 || !strings.HasPrefix(os.Args[i], "-") || !strings.HasPrefix(argument, configFlag) {
+			arguments = append(arguments, os.Args[i])
+			continue
+		}
+		if argument == configFlag && i+1 < len(os.Args) {
+			file = os.Args[i+1]
+			i++
+		} else if strings.HasPrefix(argument, configFlag+"=") {
+			file = argument[len(configFlag+"="):]
+		} else {
+			arguments = append(arguments, os.Args[i])
+		}
+	}
+	os.Args = arguments
+	if len(file) == 0 {
+		file, err = configuration.Find(working)
+		if err != nil {
+			return err
+		}
+	}
+	file, err = filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(file); err == nil && info.IsDir() {
+		file, _ = configuration.Lookup(file)
+	}
+	err = os.Chdir(filepath.Dir(file))
+	if err != nil {
+		return err
+	}
+	configuration.Use(filepath.Base(file))
+	return nil
+}
+
+// workingPath returns a command line path relative to the directory the command was invoked from.
+func workingPath(path string) string {
+	if len(path) == 0 || path == stdout || filepath.IsAbs(path) || len(working) == 0 {
+		return path
+	}
+	return filepath.Join(working, path)
+}
+
+// openConfiguration reads the configuration file and reports the tasks that sanitization changed to stderr.
+func openConfiguration() (configuration.File, error) {
+	config, err := configuration.Open()
+	if err != nil {
+		return config, fmt.Errorf(color(fmt.Sprintf("%s: %s", configuration.Path(), err.Error()), Red, false))
+	}
+	for _, name := range config.Sanitized() {
+		fmt.Fprintln(os.Stderr, fmt.Sprintf("[\x1b[33;1m%s\x1b[0m] ! %s: %s task is not sanitized; the next init, update or delete saves the sanitized task", time.Now().Format(time.StampMicro), configuration.Path(), name))
+	}
+	return config, nil
+}
+
+// runnableTask returns the resolved task; abstract tasks cannot be run.
+func runnableTask(config configuration.File, name string) (task configuration.Task, err error) {
+	if !config.HasTask(configuration.Task{Name: name}) {
+		return task, fmt.Errorf(fmt.Sprintf("%s %s", color(name, Red, false), "is not a valid task"))
+	}
+	task, err = config.Resolve(configuration.Task{Name: name})
+	if err != nil {
+		return task, fmt.Errorf(color(err.Error(), Red, false))
+	}
+	if task.Abstract {
+		return task, fmt.Errorf(fmt.Sprintf("%s %s", color(name, Red, false), "is an abstract task and cannot be run"))
+	}
+	return task, nil
+}
+
+// runnableGroup returns the task names of the group with the environment variables replaced.
+func runnableGroup(config configuration.File, name string) (tasks []string, err error) {
+	if !config.HasGroup(configuration.Group{Name: name}) {
+		return nil, fmt.Errorf(fmt.Sprintf("%s %s", color(name, Red, false), "is not a valid group"))
+	}
+	group, err := config.ResolveGroup(configuration.Group{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf(color(err.Error(), Red, false))
+	}
+	return group.Tasks, nil
+}
+
+func parseConfig() (err error) {
+	subcommand := ""
+	if len(os.Args) > 2 {
+		subcommand = os.Args[2]
+	}
+	if subcommand != "print" {
+		usageConfig()
+		if len(subcommand) == 0 || strings.HasPrefix(subcommand, "-") {
+			return nil
+		}
+		return fmt.Errorf("emits config %s: unknown command", subcommand)
+	}
+
+	helpFlag := flag.Bool("h", false, "")
+	flagSet := flag.NewFlagSet("config", flag.ExitOnError)
+	resolvedFlag := flagSet.Bool("resolved", false, "")
+	flagSet.Usage = func() {
+		usageConfig()
+	}
+	flagSet.BoolVar(helpFlag, "h", false, "")
+	flagSet.BoolVar(helpFlag, "help", false, "")
+	flagSet.Parse(os.Args[3:])
+
+	config, err := openConfiguration()
+	if err != nil {
+		return err
+	}
+	if *resolvedFlag {
+		config, err = config.Resolved()
+		if err != nil {
+			return fmt.Errorf(color(err.Error(), Red, false))
+		}
+	}
+	output, err := json.MarshalIndent(config, "", "\t")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+func usageConfig() {
+	fmt.Println("")
+	fmt.Println("Usage:")
+	fmt.Println("")
+	fmt.Println(color("emits config print", Cyan, true), color("[flag]", Green, true))
+	fmt.Println("")
+	fmt.Println("Prints the configuration file as json; ${NAME} and ${NAME:-default} environment variables are replaced in the resolved configuration, never in the file")
+	fmt.Println("")
+	fmt.Println("The flag is:")
+	fmt.Println("")
+	fmt.Println(argument("resolved", "resolve extended tasks and environment variables; undefined variables without a default are errors", Green))
+	fmt.Println("")
+}
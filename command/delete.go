@@ -25,7 +25,7 @@ func parseDelete() (err error) {
 		return fmt.Errorf("\x1b[31;1m%s\x1b[0m", "a task argument is required")
 	}
 
-	config, err := configuration.Open()
+	config, err := openConfiguration()
 	if err != nil {
 		return err
 	}
@@ -36,8 +36,38 @@ func parseDelete() (err error) {
 		return fmt.Errorf("`\x1b[31;1m%s\x1b[0m` \x1b[31;1mis not a valid task\x1b[0m", name)
 	}
 
+	for _, t := range config.Tasks {
+		if strings.EqualFold(strings.TrimSpace(t.Extends), name) {
+			return fmt.Errorf(fmt.Sprintf("%s %s %s", color(name, Red, false), "is extended by the task", t.Name))
+		}
+	}
+	var groups []configuration.Group
+	for _, g := range config.Groups {
+		if !member(g, name) {
+			continue
+		}
+		var remaining []string
+		for _, t := range g.Tasks {
+			if !strings.EqualFold(t, name) {
+				remaining = append(remaining, t)
+			}
+		}
+		if len(remaining) == 0 {
+			return fmt.Errorf(fmt.Sprintf("%s %s %s", color(name, Red, false), "is the only task of the group", g.Name))
+		}
+		g.Tasks = remaining
+		groups = append(groups, g)
+	}
+
 	ok := config.DeleteTask(task)
 	if ok {
+		for _, g := range groups {
+			for i := range config.Groups {
+				if config.Groups[i].Name == g.Name {
+					config.Groups[i] = g
+				}
+			}
+		}
 		err := config.Write()
 		if err != nil {
 			return err
@@ -46,6 +76,9 @@ func parseDelete() (err error) {
 		return fmt.Errorf("task could not be removed")
 	}
 	fmt.Println(fmt.Sprintf("`%v` task configuration deleted", task.Name))
+	for _, g := range groups {
+		fmt.Println(fmt.Sprintf("`%v` group configuration updated", g.Name))
+	}
 	return nil
 }
 
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/emits-io/emits/generate"
+)
+
+func parseGenerate() (err error) {
+	language := ""
+	if len(os.Args) > 2 {
+		language = os.Args[2]
+	}
+	if language != "go" {
+		usageGenerate()
+		if len(language) == 0 || strings.HasPrefix(language, "-") {
+			return nil
+		}
+		return fmt.Errorf("emits generate %s: unknown language", language)
+	}
+
+	helpFlag := flag.Bool("h", false, "")
+	flagSet := flag.NewFlagSet("generate", flag.ExitOnError)
+	taskFlag := flagSet.String("task", "", "")
+	packageFlag := flagSet.String("package", "", "")
+	outputFlag := flagSet.String("output", "", "")
+	flagSet.Usage = func() {
+		usageGenerate()
+	}
+	flagSet.BoolVar(helpFlag, "h", false, "")
+	flagSet.BoolVar(helpFlag, "help", false, "")
+	flagSet.Parse(os.Args[3:])
+
+	name := strings.ToLower(strings.Replace(*taskFlag, " ", "", -1))
+	packageName := strings.TrimSpace(*packageFlag)
+	if len(name) == 0 || len(packageName) == 0 {
+		usageGenerate()
+		return fmt.Errorf(color("task and package arguments are required\n", Red, false))
+	}
+
+	config, err := openConfiguration()
+	if err != nil {
+		return err
+	}
+
+	task, err := runnableTask(config, name)
+	if err != nil {
+		return err
+	}
+	fmt.Println(fmt.Sprintf("[\x1b[32;1m%s\x1b[0m] %v go", time.Now().Format(time.StampMicro), task.Name))
+	documents, err := readDocuments(task)
+	if err != nil {
+		return err
+	}
+	source, err := generate.Go(task, documents, packageName)
+	if err != nil {
+		return err
+	}
+	output := workingPath(*outputFlag)
+	if len(output) == 0 {
+		output = filepath.Join(task.OutputDirectory(), "go", packageName, packageName+".go")
+	}
+	err = os.MkdirAll(filepath.Dir(output), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(output, source, 0644)
+	if err != nil {
+		return err
+	}
+	fmt.Println(fmt.Sprintf("[\x1b[32;1m%s\x1b[0m] generated %s", time.Now().Format(time.StampMicro), output))
+	return nil
+}
+
+func usageGenerate() {
+	fmt.Println("")
+	fmt.Println("Usage:")
+	fmt.Println("")
+	fmt.Println(color("emits generate go", Cyan, true), color("[arguments]", Magenta, true))
+	fmt.Println("")
+	fmt.Println("Generates go source from the go mappings of a task.")
+	fmt.Println("")
+	fmt.Println("The arguments are:")
+	fmt.Println("")
+	fmt.Println(argument("task", "name of the configuration task", Magenta))
+	fmt.Println(argument("package", "name of the generated go package", Magenta))
+	fmt.Println(argument("output", "output path of the generated go file", Magenta))
+	fmt.Println("")
+}
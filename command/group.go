@@ -0,0 +1,356 @@
+package command
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/emits-io/emits/configuration"
+)
+
+var isUniqueGroupName uniqueOperator = func(value string, config configuration.File) bool {
+	return !config.HasGroup(configuration.Group{Name: value})
+}
+
+func parseGroup() (err error) {
+	subcommand := ""
+	if len(os.Args) > 2 {
+		subcommand = os.Args[2]
+	}
+	switch subcommand {
+	case "create":
+		return parseGroupCreate()
+	case "add":
+		return parseGroupMembers(subcommand)
+	case "remove":
+		return parseGroupMembers(subcommand)
+	case "delete":
+		return parseGroupDelete()
+	case "list":
+		return parseGroupList()
+	}
+	usageGroup()
+	if len(subcommand) == 0 || strings.HasPrefix(subcommand, "-") {
+		return nil
+	}
+	return fmt.Errorf("emits group %s: unknown command", subcommand)
+}
+
+func parseGroupCreate() (err error) {
+	helpFlag := flag.Bool("h", false, "")
+	flagSet := flag.NewFlagSet("group create", flag.ExitOnError)
+	nameFlag := flagSet.String("name", "", "")
+	tasksFlag := flagSet.String("tasks", "", "")
+	noPromptFlag := flagSet.Bool("no-prompt", false, "")
+	flagSet.Usage = func() {
+		usageGroup()
+	}
+	flagSet.BoolVar(helpFlag, "h", false, "")
+	flagSet.BoolVar(helpFlag, "help", false, "")
+	flagSet.Parse(os.Args[3:])
+	if *helpFlag {
+		usageGroup()
+		return nil
+	}
+
+	config, err := openConfiguration()
+	if err != nil {
+		return err
+	}
+	reader := bufio.NewReader(os.Stdin)
+	name := strings.ToLower(strings.Replace(*nameFlag, " ", "", -1))
+	tasks := strings.TrimSpace(*tasksFlag)
+	if *noPromptFlag {
+		if len(name) == 0 || len(tasks) == 0 {
+			return fmt.Errorf(color("name and tasks arguments are required with the no-prompt flag", Red, false))
+		}
+		if !isUniqueGroupName(name, config) {
+			return fmt.Errorf(fmt.Sprintf("%s %s", color(name, Red, false), "group name is already taken"))
+		}
+	}
+	if len(name) == 0 {
+		name = strings.ToLower(strings.Replace(readFlag(reader, "group name", true), " ", "", -1))
+	}
+	name = uniqueFlag(reader, "group name", "is already taken", true, name, config, isUniqueGroupName)
+	if len(tasks) == 0 && len(name) > 0 {
+		tasks = strings.TrimSpace(readFlag(reader, "group task names", true))
+	}
+	if len(name) == 0 || len(tasks) == 0 {
+		return fmt.Errorf(color("\ngroup name and task names are required", Red, false))
+	}
+	group := configuration.Group{Name: name}
+	group.Tasks, err = groupTasks(config, tasks)
+	if err != nil {
+		return err
+	}
+	if !confirmGroup(reader, *noPromptFlag, group, "added to") {
+		return nil
+	}
+	err = config.CreateGroup(group)
+	if err != nil {
+		return err
+	}
+	err = config.Write()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Use", color(fmt.Sprintf("emits run %s%s", color("--group ", Magenta, true), color(group.Name, Magenta, false)), Cyan, true), "to emit this group")
+	fmt.Println("")
+	return nil
+}
+
+func parseGroupMembers(subcommand string) (err error) {
+	helpFlag := flag.Bool("h", false, "")
+	flagSet := flag.NewFlagSet("group "+subcommand, flag.ExitOnError)
+	groupFlag := flagSet.String("group", "", "")
+	tasksFlag := flagSet.String("tasks", "", "")
+	noPromptFlag := flagSet.Bool("no-prompt", false, "")
+	flagSet.Usage = func() {
+		usageGroup()
+	}
+	flagSet.BoolVar(helpFlag, "h", false, "")
+	flagSet.BoolVar(helpFlag, "help", false, "")
+	flagSet.Parse(os.Args[3:])
+
+	name := strings.ToLower(strings.Replace(*groupFlag, " ", "", -1))
+	if len(name) == 0 || len(strings.TrimSpace(*tasksFlag)) == 0 {
+		usageGroup()
+		return fmt.Errorf(color("group and tasks arguments are required\n", Red, false))
+	}
+
+	config, err := openConfiguration()
+	if err != nil {
+		return err
+	}
+	if !config.HasGroup(configuration.Group{Name: name}) {
+		return fmt.Errorf(fmt.Sprintf("%s %s", color(name, Red, false), "is not a valid group"))
+	}
+	group := config.GetGroup(configuration.Group{Name: name})
+
+	tasks := strings.Fields(strings.ToLower(*tasksFlag))
+	if subcommand == "add" {
+		tasks, err = groupTasks(config, *tasksFlag)
+		if err != nil {
+			return err
+		}
+		for _, t := range tasks {
+			if !member(group, t) {
+				group.Tasks = append(group.Tasks, t)
+			}
+		}
+	} else {
+		for _, t := range tasks {
+			if !member(group, t) {
+				return fmt.Errorf(fmt.Sprintf("%s %s %s", color(t, Red, false), "is not a task of the group", group.Name))
+			}
+		}
+		var remaining []string
+		for _, t := range group.Tasks {
+			removed := false
+			for _, r := range tasks {
+				removed = removed || strings.EqualFold(t, r)
+			}
+			if !removed {
+				remaining = append(remaining, t)
+			}
+		}
+		if len(remaining) == 0 {
+			return fmt.Errorf(color(fmt.Sprintf("%s group requires at least one task; use emits group delete to delete the group", group.Name), Red, false))
+		}
+		group.Tasks = remaining
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	if !confirmGroup(reader, *noPromptFlag, group, "updated in") {
+		return nil
+	}
+	err = config.UpdateGroup(group)
+	if err != nil {
+		return err
+	}
+	fmt.Println(fmt.Sprintf("`%v` group configuration updated", group.Name))
+	fmt.Println("")
+	return nil
+}
+
+func parseGroupDelete() (err error) {
+	helpFlag := flag.Bool("h", false, "")
+	flagSet := flag.NewFlagSet("group delete", flag.ExitOnError)
+	groupFlag := flagSet.String("group", "", "")
+	noPromptFlag := flagSet.Bool("no-prompt", false, "")
+	flagSet.Usage = func() {
+		usageGroup()
+	}
+	flagSet.BoolVar(helpFlag, "h", false, "")
+	flagSet.BoolVar(helpFlag, "help", false, "")
+	flagSet.Parse(os.Args[3:])
+
+	name := strings.ToLower(strings.Replace(*groupFlag, " ", "", -1))
+	if len(name) == 0 {
+		return fmt.Errorf("\x1b[31;1m%s\x1b[0m", "a group argument is required")
+	}
+
+	config, err := openConfiguration()
+	if err != nil {
+		return err
+	}
+	group := configuration.Group{Name: name}
+	if !config.HasGroup(group) {
+		return fmt.Errorf("`\x1b[31;1m%s\x1b[0m` \x1b[31;1mis not a valid group\x1b[0m", name)
+	}
+	group = config.GetGroup(group)
+
+	reader := bufio.NewReader(os.Stdin)
+	if !confirmGroup(reader, *noPromptFlag, group, "deleted from") {
+		return nil
+	}
+	if !config.DeleteGroup(group) {
+		return fmt.Errorf("group could not be removed")
+	}
+	err = config.Write()
+	if err != nil {
+		return err
+	}
+	fmt.Println(fmt.Sprintf("`%v` group configuration deleted", group.Name))
+	fmt.Println("")
+	return nil
+}
+
+func parseGroupList() (err error) {
+	helpFlag := flag.Bool("h", false, "")
+	flagSet := flag.NewFlagSet("group list", flag.ExitOnError)
+	flagSet.Usage = func() {
+		usageGroup()
+	}
+	flagSet.BoolVar(helpFlag, "h", false, "")
+	flagSet.BoolVar(helpFlag, "help", false, "")
+	flagSet.Parse(os.Args[3:])
+
+	config, err := openConfiguration()
+	if err != nil {
+		return err
+	}
+	fmt.Println("")
+	fmt.Println("The following groups have been configured:")
+	fmt.Println("")
+	listGroups(config)
+	fmt.Println("")
+	return nil
+}
+
+// listGroups prints the groups with their tasks; tasks that do not exist are red.
+func listGroups(config configuration.File) {
+	for _, g := range config.Groups {
+		var tasks []string
+		resolved, err := config.ResolveGroup(g)
+		if err != nil {
+			resolved = g
+		}
+		for k, t := range g.Tasks {
+			if config.HasTask(configuration.Task{Name: resolved.Tasks[k]}) {
+				tasks = append(tasks, t)
+			} else {
+				tasks = append(tasks, color(t, Red, false))
+			}
+		}
+		fmt.Println(fmt.Sprintf("%s • %s", g.Name, strings.Join(tasks, " ")))
+	}
+}
+
+// groupTasks returns the space delimited task names; every task must exist and must not be abstract.
+func groupTasks(config configuration.File, names string) (tasks []string, err error) {
+	var missing []string
+	var abstract []string
+	for _, name := range strings.Fields(strings.ToLower(names)) {
+		if !config.HasTask(configuration.Task{Name: name}) {
+			missing = append(missing, name)
+			continue
+		}
+		if config.GetTask(configuration.Task{Name: name}).Abstract {
+			abstract = append(abstract, name)
+			continue
+		}
+		duplicate := false
+		for _, t := range tasks {
+			duplicate = duplicate || t == name
+		}
+		if !duplicate {
+			tasks = append(tasks, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf(fmt.Sprintf("%s %s", color(strings.Join(missing, " "), Red, false), "is not a valid task"))
+	}
+	if len(abstract) > 0 {
+		return nil, fmt.Errorf(fmt.Sprintf("%s %s", color(strings.Join(abstract, " "), Red, false), "is an abstract task and cannot be run"))
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf(color("at least one task is required", Red, false))
+	}
+	return tasks, nil
+}
+
+func member(group configuration.Group, task string) bool {
+	for _, t := range group.Tasks {
+		if strings.EqualFold(t, task) {
+			return true
+		}
+	}
+	return false
+}
+
+// confirmGroup prints the group and returns true if the change is confirmed; changes are confirmed without a prompt.
+func confirmGroup(reader *bufio.Reader, noPrompt bool, group configuration.Group, change string) bool {
+	fmt.Println("")
+	if noPrompt == false {
+		fmt.Println(fmt.Sprintf("The following group will be %s the configuration file:", change))
+	} else {
+		fmt.Println(fmt.Sprintf("The following group has been %s the configuration file:", change))
+	}
+	fmt.Println("")
+	preview, _ := json.MarshalIndent(group, "", "\t")
+	fmt.Println(fmt.Sprintf("%v", string(preview)))
+	fmt.Println("")
+	if noPrompt {
+		return true
+	}
+	fmt.Println("Type", color("yes", Red, true), "below to continue...")
+	fmt.Println("")
+	input := strings.TrimSpace(readFlag(reader, "Is this OK?", true))
+	fmt.Println("")
+	if strings.ToLower(input) == "yes" {
+		return true
+	}
+	fmt.Println("Use", color("emits help group", Cyan, true), "for more information")
+	fmt.Println("")
+	return false
+}
+
+func usageGroup() {
+	fmt.Println("")
+	fmt.Println("Usage:")
+	fmt.Println("")
+	fmt.Println(color("emits group", Cyan, true), color("<command>", Cyan, true), color("[arguments]", Magenta, true), color("[flags]", Green, true))
+	fmt.Println("")
+	fmt.Println("The commands are:")
+	fmt.Println("")
+	fmt.Println(command("create", "create a group of tasks", Cyan))
+	fmt.Println(command("add", "add tasks to a group", Cyan))
+	fmt.Println(command("remove", "remove tasks from a group", Cyan))
+	fmt.Println(command("delete", "delete a group", Cyan))
+	fmt.Println(command("list", "list all groups and their tasks", Cyan))
+	fmt.Println("")
+	fmt.Println("The arguments are:")
+	fmt.Println("")
+	fmt.Println(argument("name", "name of the group to create", Magenta))
+	fmt.Println(argument("group", "name of the group to change", Magenta))
+	fmt.Println(argument("tasks", "space delimited task names", Magenta))
+	fmt.Println("")
+	fmt.Println("The flag is:")
+	fmt.Println("")
+	fmt.Println(argument("no-prompt", "do not prompt for confirmation or missing arguments; missing required arguments are errors", Green))
+	fmt.Println("")
+}
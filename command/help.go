@@ -30,6 +30,27 @@ func parseHelp(command string) (err error) {
 	case "version":
 		usageVersion()
 		return nil
+	case "render":
+		usageRender()
+		return nil
+	case "site":
+		usageSite()
+		return nil
+	case "schema":
+		usageSchema()
+		return nil
+	case "generate":
+		usageGenerate()
+		return nil
+	case "validate":
+		usageValidate()
+		return nil
+	case "group":
+		usageGroup()
+		return nil
+	case "config":
+		usageConfig()
+		return nil
 	}
 	return fmt.Errorf("emits help %s: unknown command", command)
 }
@@ -48,8 +69,19 @@ func usageHelp() {
 	fmt.Println(command("serve", "serve files for a configuration task", Cyan))
 	fmt.Println(command("update", "update configration task fields", Cyan))
 	fmt.Println(command("delete", "delete configuration task", Cyan))
+	fmt.Println(command("group", "create, change, delete or list task groups", Cyan))
+	fmt.Println(command("render", "render files for a configuration task", Cyan))
+	fmt.Println(command("site", "generate a static site for a configuration task", Cyan))
+	fmt.Println(command("generate", "generate source code for a configuration task", Cyan))
+	fmt.Println(command("schema", "print the json schema of emitted files", Cyan))
+	fmt.Println(command("validate", "check the configuration file for problems", Cyan))
+	fmt.Println(command("config", "print the configuration file", Cyan))
 	fmt.Println(command("version", "print command line interface version", Cyan))
 	fmt.Println("")
+	fmt.Println("The global arguments are:")
+	fmt.Println("")
+	fmt.Println(argument("config", "path of the configuration file; defaults to EMITS_CONFIG or the nearest emits.json, emits.yaml or emits.toml", Magenta))
+	fmt.Println("")
 	fmt.Println("Use", color("emits help", Cyan, false), color("<command>", Cyan, true), "for more information")
 	fmt.Println("")
 }
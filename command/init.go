@@ -12,7 +12,7 @@ import (
 )
 
 func parseInit() (err error) {
-	config, err := configuration.Open()
+	config, err := openConfiguration()
 	if err != nil {
 		return err
 	}
@@ -82,7 +82,7 @@ func parseInit() (err error) {
 	task := configuration.Task{
 		Name:        name,
 		Description: description,
-		Source:      *sourceFlag,
+		Source:      sourceFlag,
 		Comment: configuration.Comment{
 			Block: configuration.Block{
 				Open:  commentOpen,
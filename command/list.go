@@ -1,11 +1,10 @@
 package command
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
-
-	"github.com/emits-io/emits/configuration"
 )
 
 func parseList() (err error) {
@@ -13,6 +12,7 @@ func parseList() (err error) {
 	helpFlag := flag.Bool("h", false, "")
 	flagSet := flag.NewFlagSet("list", flag.ExitOnError)
 	descriptionFlag := flagSet.Bool("description", false, "")
+	resolvedFlag := flagSet.Bool("resolved", false, "")
 	flagSet.Usage = func() {
 		usageList()
 	}
@@ -20,7 +20,7 @@ func parseList() (err error) {
 	flagSet.BoolVar(helpFlag, "help", false, "")
 	flagSet.Parse(os.Args[2:])
 
-	config, err := configuration.Open()
+	config, err := openConfiguration()
 	if err != nil {
 		return err
 	}
@@ -34,9 +34,29 @@ func parseList() (err error) {
 		if *descriptionFlag {
 			description = fmt.Sprintf(" • %s", task.Description)
 		}
-		fmt.Println(fmt.Sprintf("%s%s", task.Name, description))
+		abstract := ""
+		if task.Abstract {
+			abstract = color(" (abstract)", Yellow, false)
+		}
+		fmt.Println(fmt.Sprintf("%s%s%s", task.Name, abstract, description))
+		if *resolvedFlag {
+			resolved, err := config.Resolve(task)
+			if err != nil {
+				fmt.Println(color(err.Error(), Red, false))
+				continue
+			}
+			preview, _ := json.MarshalIndent(resolved, "", "\t")
+			fmt.Println(string(preview))
+			fmt.Println("")
+		}
 	}
 	fmt.Println("")
+	if len(config.Groups) > 0 {
+		fmt.Println("The following groups have been configured:")
+		fmt.Println("")
+		listGroups(config)
+		fmt.Println("")
+	}
 	return nil
 }
 
@@ -44,10 +64,11 @@ func usageList() {
 	fmt.Println("")
 	fmt.Println("Usage:")
 	fmt.Println("")
-	fmt.Println(color("emits list", Cyan, true), color("[flag]", Green, true))
+	fmt.Println(color("emits list", Cyan, true), color("[flags]", Green, true))
 	fmt.Println("")
-	fmt.Println("The flag is:")
+	fmt.Println("The flags are:")
 	fmt.Println("")
 	fmt.Println(argument("description", "output description with task name", Green))
+	fmt.Println(argument("resolved", "output each task with the tasks it extends merged in", Green))
 	fmt.Println("")
 }
@@ -0,0 +1,78 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/emits-io/emits/configuration"
+)
+
+const (
+	// runIndex file name of the index of the files emitted by run
+	runIndex = "emits.json"
+	// renderIndex file name of the index of the files rendered by render
+	renderIndex = ".emits.json"
+)
+
+// writableOutput returns an error if the output directory contains the configuration file.
+func writableOutput(output string) error {
+	directory, err := filepath.Abs(output)
+	if err != nil {
+		return err
+	}
+	current, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if within(directory, current) {
+		return fmt.Errorf(fmt.Sprintf("%s %s", color(output, Red, false), "output directory contains the configuration file"))
+	}
+	return nil
+}
+
+// removeEmitted removes the files listed by the index of a previous run within the output directory and the directories they leave empty; other files are never removed.
+func removeEmitted(output string, name string) error {
+	file := filepath.Join(output, name)
+	content, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	index := configuration.Index{}
+	err = json.Unmarshal(content, &index)
+	if err != nil {
+		return fmt.Errorf(fmt.Sprintf("%s %s", color(file, Red, false), "index is not valid; the previous output cannot be removed"))
+	}
+	files := append(index.Files, file)
+	if len(index.Bundle) > 0 {
+		files = append(files, index.Bundle)
+	}
+	for _, f := range files {
+		f = filepath.FromSlash(f)
+		if !within(output, f) || filepath.Clean(f) == filepath.Clean(output) {
+			continue
+		}
+		err = os.Remove(f)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		for directory := filepath.Dir(f); within(output, directory) && filepath.Clean(directory) != filepath.Clean(output); directory = filepath.Dir(directory) {
+			if os.Remove(directory) != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// within returns true if the path is the directory or is within it.
+func within(directory string, path string) bool {
+	relative, err := filepath.Rel(directory, path)
+	return err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(os.PathSeparator))
+}
@@ -17,6 +17,10 @@ type Color string
 const (
 	// emits constant
 	emits = "emits"
+	// stdout output argument value
+	stdout = "-"
+	// formatNDJSON newline delimited json stream format
+	formatNDJSON = "ndjson"
 	//Black Color
 	Black Color = "\u001b[30"
 	//Red Color
@@ -62,6 +66,20 @@ func Parse(command string) (err error) {
 		return parseUpdate()
 	case "version":
 		return parseVersion()
+	case "render":
+		return parseRender()
+	case "site":
+		return parseSite()
+	case "schema":
+		return parseSchema()
+	case "generate":
+		return parseGenerate()
+	case "validate":
+		return parseValidate()
+	case "group":
+		return parseGroup()
+	case "config":
+		return parseConfig()
 	}
 	return fmt.Errorf("emits %s: unknown command", command)
 }
@@ -83,9 +101,9 @@ func readFlag(rd *bufio.Reader, name string, required bool) string {
 		indicator = color("required", Red, true)
 	}
 	fmt.Printf("%s %s: ", name, indicator)
-	input, _ := rd.ReadString('\n')
+	input, err := rd.ReadString('\n')
 	input = strings.TrimSpace(input)
-	if required && len(input) == 0 {
+	if required && len(input) == 0 && err == nil {
 		return readFlag(rd, name, required)
 	}
 	return input
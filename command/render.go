@@ -0,0 +1,229 @@
+package command
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/emits-io/emits/configuration"
+	"github.com/emits-io/emits/data"
+	"github.com/emits-io/emits/render"
+)
+
+type renderer func(task configuration.Task, documents []data.Document) (pages []render.Page, err error)
+
+var renderers = map[string]renderer{
+	"markdown": func(task configuration.Task, documents []data.Document) ([]render.Page, error) {
+		return render.Markdown(task, documents), nil
+	},
+	"template": render.Templates,
+	"site":     render.Site,
+	"openapi":  render.OpenAPI,
+	"man":      render.Man,
+}
+
+func parseRender() (err error) {
+	name := ""
+	if len(os.Args) > 2 {
+		name = os.Args[2]
+	}
+	if _, ok := renderers[name]; !ok {
+		usageRender()
+		if len(name) == 0 || strings.HasPrefix(name, "-") {
+			return nil
+		}
+		return fmt.Errorf("emits render %s: unknown renderer", name)
+	}
+	return parseRenderer(name, os.Args[3:], usageRender)
+}
+
+func parseSite() (err error) {
+	return parseRenderer("site", os.Args[2:], usageSite)
+}
+
+func parseRenderer(name string, arguments []string, usage func()) (err error) {
+	helpFlag := flag.Bool("h", false, "")
+	flagSet := flag.NewFlagSet(name, flag.ExitOnError)
+	taskFlag := flagSet.String("task", "", "")
+	groupFlag := flagSet.String("group", "", "")
+	outputFlag := flagSet.String("output", "", "")
+	flagSet.Usage = func() {
+		usage()
+	}
+	flagSet.BoolVar(helpFlag, "h", false, "")
+	flagSet.BoolVar(helpFlag, "help", false, "")
+	flagSet.Parse(arguments)
+
+	taskName := strings.ToLower(strings.Replace(*taskFlag, " ", "", -1))
+	groupName := strings.ToLower(strings.Replace(*groupFlag, " ", "", -1))
+
+	if len(taskName) == 0 && len(groupName) == 0 {
+		usage()
+		return fmt.Errorf(color("task or group argument is required\n", Red, false))
+	}
+
+	config, err := openConfiguration()
+	if err != nil {
+		return err
+	}
+
+	if len(groupName) > 0 {
+		tasks, err := runnableGroup(config, groupName)
+		if err != nil {
+			return err
+		}
+		for _, t := range tasks {
+			if err := renderTask(config, t, name, workingPath(*outputFlag)); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+			}
+		}
+	} else if len(taskName) > 0 {
+		err := renderTask(config, taskName, name, workingPath(*outputFlag))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderTask(config configuration.File, name string, rendererName string, output string) (err error) {
+	task, err := runnableTask(config, name)
+	if err != nil {
+		return err
+	}
+	fmt.Println(fmt.Sprintf("[\x1b[32;1m%s\x1b[0m] %v %s", time.Now().Format(time.StampMicro), task.Name, rendererName))
+	documents, err := readDocuments(task)
+	if err != nil {
+		return err
+	}
+	pages, err := renderers[rendererName](task, documents)
+	if err != nil {
+		return err
+	}
+	if len(output) == 0 {
+		output = filepath.Join(task.OutputDirectory(), rendererName)
+	}
+	err = writableOutput(output)
+	if err != nil {
+		return err
+	}
+	err = removeEmitted(output, renderIndex)
+	if err != nil {
+		return err
+	}
+	files, err := render.Write(output, pages)
+	if err != nil {
+		return err
+	}
+	index, err := json.MarshalIndent(configuration.Index{SchemaVersion: data.SchemaVersion, Files: append([]string{}, files...)}, "", "\t")
+	if err == nil {
+		err = os.MkdirAll(output, os.ModePerm)
+	}
+	if err == nil {
+		err = ioutil.WriteFile(filepath.Join(output, renderIndex), index, 0644)
+	}
+	if err != nil {
+		return err
+	}
+	plural := "s"
+	if len(files) == 1 {
+		plural = ""
+	}
+	fmt.Println(fmt.Sprintf("[\x1b[32;1m%s\x1b[0m] %v file%s rendered to %s", time.Now().Format(time.StampMicro), len(files), plural, output))
+	return nil
+}
+
+// readDocuments returns the documents listed by the run index of the task output directory; the task files are parsed when there is no index or its documents cannot be read, diagnostics are printed and files that cannot be emitted are skipped.
+func readDocuments(task configuration.Task) (documents []data.Document, err error) {
+	err = data.ValidateTimestamp(task)
+	if err != nil {
+		return nil, fmt.Errorf(fmt.Sprintf("%s %s", color(task.Name, Red, false), err.Error()))
+	}
+	documents, ok := emittedDocuments(task)
+	if ok {
+		return documents, nil
+	}
+	matches, err := task.Files()
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range matches {
+		read, diagnostics, err := data.Read(filepath.Join(file), task)
+		for _, d := range diagnostics {
+			fmt.Println(fmt.Sprintf("[\x1b[33;1m%s\x1b[0m] ! %s", time.Now().Format(time.StampMicro), d))
+		}
+		if err == nil {
+			documents = append(documents, read...)
+		}
+	}
+	return documents, nil
+}
+
+// emittedDocuments returns the documents of the files listed by the run index of the task output directory; ok is false when there is no index, a file is not a node mode json document or it is not the task output file of its document.
+func emittedDocuments(task configuration.Task) (documents []data.Document, ok bool) {
+	output := task.OutputDirectory()
+	content, err := ioutil.ReadFile(filepath.Join(output, runIndex))
+	if err != nil {
+		return nil, false
+	}
+	index := configuration.Index{}
+	err = json.Unmarshal(content, &index)
+	if err != nil || !data.Compatible(index.SchemaVersion) {
+		return nil, false
+	}
+	documents = []data.Document{}
+	for _, file := range index.Files {
+		file = filepath.FromSlash(file)
+		read, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, false
+		}
+		document, err := data.Emitted(read)
+		if err != nil || filepath.Join(output, task.OutputFile(document.Source(), document.File.Document, filepath.Ext(file))) != filepath.Clean(file) {
+			return nil, false
+		}
+		documents = append(documents, document)
+	}
+	return documents, true
+}
+
+func usageRender() {
+	fmt.Println("")
+	fmt.Println("Usage:")
+	fmt.Println("")
+	fmt.Println(color("emits render", Cyan, true), color("<renderer>", Cyan, true), color("[arguments]", Magenta, true))
+	fmt.Println("")
+	fmt.Println("The renderers are:")
+	fmt.Println("")
+	fmt.Println(command("markdown", "markdown pages and a table of contents", Cyan))
+	fmt.Println(command("template", "go text or html templates configured by the task", Cyan))
+	fmt.Println(command("site", "static html documentation site", Cyan))
+	fmt.Println(command("openapi", "openapi document from route annotations", Cyan))
+	fmt.Println(command("man", "roff man pages from command annotations", Cyan))
+	fmt.Println("")
+	fmt.Println("The arguments are:")
+	fmt.Println("")
+	fmt.Println(argument("task", "name of the configuration task", Magenta))
+	fmt.Println(argument("group", "name of the configuration group", Magenta))
+	fmt.Println(argument("output", "output path of the rendered files", Magenta))
+	fmt.Println("")
+}
+
+func usageSite() {
+	fmt.Println("")
+	fmt.Println("Usage:")
+	fmt.Println("")
+	fmt.Println(color("emits site", Cyan, true), color("[arguments]", Magenta, true))
+	fmt.Println("")
+	fmt.Println("The arguments are:")
+	fmt.Println("")
+	fmt.Println(argument("task", "name of the configuration task", Magenta))
+	fmt.Println(argument("group", "name of the configuration group", Magenta))
+	fmt.Println(argument("output", "output path of the static site", Magenta))
+	fmt.Println("")
+}
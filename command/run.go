@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -20,6 +21,9 @@ func parseRun() (err error) {
 	taskFlag := flagSet.String("task", "", "")
 	groupFlag := flagSet.String("group", "", "")
 	outputFlag := flagSet.String("output", "", "")
+	bundleFlag := flagSet.Bool("bundle", false, "")
+	gzipFlag := flagSet.Bool("gzip", false, "")
+	formatFlag := flagSet.String("format", "", "")
 	flagSet.Usage = func() {
 		usageRun()
 	}
@@ -35,20 +39,49 @@ func parseRun() (err error) {
 		return fmt.Errorf(color("task or group argument is required\n", Red, false))
 	}
 
-	config, err := configuration.Open()
+	config, err := openConfiguration()
 	if err != nil {
 		return err
 	}
 
+	options := runOptions{
+		output:   workingPath(*outputFlag),
+		format:   strings.ToLower(strings.TrimSpace(*formatFlag)),
+		bundle:   *bundleFlag || *gzipFlag,
+		compress: *gzipFlag,
+	}
+
+	if options.output == stdout && len(options.format) == 0 {
+		options.format = formatNDJSON
+	}
+	if options.format == formatNDJSON && options.output != stdout {
+		return fmt.Errorf(color("ndjson format requires the output argument to be -", Red, false))
+	}
+	if options.output == stdout && options.format != formatNDJSON {
+		return fmt.Errorf(color("output argument - requires the ndjson format", Red, false))
+	}
+	if options.format == formatNDJSON && options.bundle {
+		return fmt.Errorf(color("bundle flag cannot be used with the ndjson format", Red, false))
+	}
+	if len(options.format) > 0 && options.format != formatNDJSON {
+		_, err := data.NewEncoder(options.format)
+		if err != nil {
+			return fmt.Errorf(fmt.Sprintf("%s %s", color(options.format, Red, false), "is not a valid format"))
+		}
+	}
+
 	if len(groupName) > 0 {
-		if !config.HasGroup(configuration.Group{Name: groupName}) {
-			return fmt.Errorf(fmt.Sprintf("%s %s", color(groupName, Red, false), "is not a valid group"))
+		tasks, err := runnableGroup(config, groupName)
+		if err != nil {
+			return err
 		}
-		for _, t := range config.GetGroup(configuration.Group{Name: groupName}).Tasks {
-			run(config, t, outputFlag)
+		for _, t := range tasks {
+			if err := run(config, t, options); err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+			}
 		}
 	} else if len(taskName) > 0 {
-		err := run(config, taskName, outputFlag)
+		err := run(config, taskName, options)
 		if err != nil {
 			return err
 		}
@@ -56,47 +89,115 @@ func parseRun() (err error) {
 	return nil
 }
 
-func run(config configuration.File, name string, outputFlag *string) (err error) {
-	if !config.HasTask(configuration.Task{Name: name}) {
-		return fmt.Errorf(fmt.Sprintf("%s %s", color(name, Red, false), "is not a valid task"))
+// runOptions structure holds the run arguments shared by every task of a group.
+type runOptions struct {
+	output   string
+	format   string
+	bundle   bool
+	compress bool
+}
+
+func run(config configuration.File, name string, options runOptions) (err error) {
+	task, err := runnableTask(config, name)
+	if err != nil {
+		return err
+	}
+	err = data.ValidateTimestamp(task)
+	if err != nil {
+		return fmt.Errorf(fmt.Sprintf("%s %s", color(task.Name, Red, false), err.Error()))
+	}
+	if options.format == formatNDJSON {
+		return stream(task)
+	}
+	if len(options.format) > 0 {
+		task.Format = options.format
 	}
-	task := config.GetTask(configuration.Task{Name: name})
 	fmt.Println(fmt.Sprintf("[\x1b[32;1m%s\x1b[0m] %v", time.Now().Format(time.StampMicro), task.Name))
 	matches, err := task.Files()
 	if err != nil {
 		return err
 	}
-	output := *outputFlag
+	output := options.output
 	if len(output) == 0 {
-		output = filepath.Join(emits, task.Name)
+		output = task.OutputDirectory()
 	}
-	err = os.RemoveAll(output)
+	err = writableOutput(output)
 	if err != nil {
-
+		return err
+	}
+	err = removeEmitted(output, runIndex)
+	if err != nil {
+		return err
 	}
-	index := configuration.Index{}
-	indexFilePath := filepath.Join(output, "emits.json")
+	index := configuration.Index{SchemaVersion: data.SchemaVersion, Files: []string{}}
+	bundle := data.NewBundle()
+	indexFilePath := filepath.Join(output, runIndex)
 	plural := "s"
 	if len(matches) == 1 {
 		plural = ""
 	}
-	fmt.Println(fmt.Sprintf("[\x1b[32;1m%s\x1b[0m] %v of %v file%s processed...", time.Now().Format(time.StampMicro), 0, len(matches), plural))
-	for i, file := range matches {
+	progress := func(processed int) {
+		fmt.Println(fmt.Sprintf("[\x1b[32;1m%s\x1b[0m] %v of %v file%s processed...", time.Now().Format(time.StampMicro), processed, len(matches), plural))
+	}
+	progress(0)
+	processed := 0
+	written := map[string]string{}
+	for _, file := range matches {
 		filePath := filepath.Join(file)
-		err := data.Write(filePath, task, output)
-		if err != nil {
-			//fmt.Println(fmt.Sprintf("[\x1b[31;1m%s\x1b[0m] ✕ %s ➤ %s", time.Now().Format(time.StampMicro), filePath, err))
-		} else {
+		documents, diagnostics, err := data.Read(filePath, task)
+		var notices []string
+		for _, d := range diagnostics {
+			notices = append(notices, fmt.Sprintf("[\x1b[33;1m%s\x1b[0m] ! %s", time.Now().Format(time.StampMicro), d))
+		}
+		var files []string
+		for _, d := range documents {
+			var file string
+			file, err = d.Write(task, output)
+			if err != nil {
+				break
+			}
+			if previous, ok := written[file]; ok {
+				notices = append(notices, fmt.Sprintf("[\x1b[33;1m%s\x1b[0m] ! %s: output of %s overwrites the output of %s", time.Now().Format(time.StampMicro), file, d.Key(), previous))
+			} else {
+				files = append(files, file)
+			}
+			written[file] = d.Key()
+			if options.bundle {
+				bundle.Add(d, task)
+			}
+		}
+		if err == nil {
+			processed++
+			index.Files = append(index.Files, files...)
+		}
+		if err == nil || len(notices) > 0 {
+			// the progress line is replaced; notices are printed above it
 			fmt.Print("\r\033[1A\033[0K")
-			fmt.Println(fmt.Sprintf("[\x1b[32;1m%s\x1b[0m] %v of %v file%s processed...", time.Now().Format(time.StampMicro), i+1, len(matches), plural))
-			index.Files = append(index.Files, filepath.Join(output, filePath+".json"))
+			for _, n := range notices {
+				fmt.Println(n)
+			}
+			progress(processed)
 		}
 	}
+	if configuration.Enabled(task.Deterministic) {
+		sort.Strings(index.Files)
+	}
+	if options.bundle {
+		file, err := bundle.Write(output, task, options.compress)
+		if err != nil {
+			return err
+		}
+		index.Bundle = file
+		fmt.Println(fmt.Sprintf("[\x1b[32;1m%s\x1b[0m] %s bundled", time.Now().Format(time.StampMicro), file))
+	}
 	file, err := json.MarshalIndent(index, "", "\t")
 	if err != nil {
 		//fmt.Println(fmt.Sprintf("[\x1b[31;1m%s\x1b[0m] ✕ %s", time.Now().Format(time.StampMicro), indexFilePath))
 	} else {
-		err = ioutil.WriteFile(indexFilePath, file, 0644)
+		err = os.MkdirAll(output, os.ModePerm)
+		if err == nil {
+			err = ioutil.WriteFile(indexFilePath, file, 0644)
+		}
 		if err != nil {
 			//fmt.Println(fmt.Sprintf("[\x1b[31;1m%s\x1b[0m] ✕ %s", time.Now().Format(time.StampMicro), indexFilePath))
 		} else {
@@ -106,16 +207,50 @@ func run(config configuration.File, name string, outputFlag *string) (err error)
 	return nil
 }
 
+// stream writes a record per document and diagnostic to stdout as each file is parsed; nothing is written to the output directory.
+func stream(task configuration.Task) (err error) {
+	matches, err := task.Files()
+	if err != nil {
+		return err
+	}
+	records := data.NewStream(os.Stdout, task)
+	for _, file := range matches {
+		documents, diagnostics, err := data.Read(filepath.Join(file), task)
+		for _, d := range diagnostics {
+			err := records.Diagnostic(d)
+			if err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			continue
+		}
+		for _, d := range documents {
+			err := records.Document(d)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func usageRun() {
 	fmt.Println("")
 	fmt.Println("Usage:")
 	fmt.Println("")
-	fmt.Println(color("emits run", Cyan, true), color("[arguments]", Magenta, true))
+	fmt.Println(color("emits run", Cyan, true), color("[arguments]", Magenta, true), color("[flags]", Green, true))
 	fmt.Println("")
 	fmt.Println("The arguments are:")
 	fmt.Println("")
 	fmt.Println(argument("task", "name of the configuration task", Magenta))
 	fmt.Println(argument("group", "name of the configuration group", Magenta))
-	fmt.Println(argument("output", "output path to emit data", Magenta))
+	fmt.Println(argument("output", "output path to emit data; - streams to stdout", Magenta))
+	fmt.Println(argument("format", "output format; json, yaml, toml or ndjson to stream records to stdout", Magenta))
+	fmt.Println("")
+	fmt.Println("The flags are:")
+	fmt.Println("")
+	fmt.Println(argument("bundle", "also emit every file into a single bundle file", Green))
+	fmt.Println(argument("gzip", "gzip compress the bundle file", Green))
 	fmt.Println("")
 }
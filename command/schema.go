@@ -0,0 +1,55 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/emits-io/emits/data"
+)
+
+func parseSchema() (err error) {
+	subcommand := ""
+	if len(os.Args) > 2 {
+		subcommand = os.Args[2]
+	}
+	if subcommand != "output" {
+		usageSchema()
+		if len(subcommand) == 0 || strings.HasPrefix(subcommand, "-") {
+			return nil
+		}
+		return fmt.Errorf("emits schema %s: unknown schema", subcommand)
+	}
+
+	helpFlag := flag.Bool("h", false, "")
+	flagSet := flag.NewFlagSet("schema", flag.ExitOnError)
+	typeFlag := flagSet.String("type", data.SchemaDocument, "")
+	flagSet.Usage = func() {
+		usageSchema()
+	}
+	flagSet.BoolVar(helpFlag, "h", false, "")
+	flagSet.BoolVar(helpFlag, "help", false, "")
+	flagSet.Parse(os.Args[3:])
+
+	schema, err := data.Schema(strings.ToLower(strings.TrimSpace(*typeFlag)))
+	if err != nil {
+		return fmt.Errorf(fmt.Sprintf("%s %s", color(*typeFlag, Red, false), "is not a valid schema type"))
+	}
+	fmt.Println(string(schema))
+	return nil
+}
+
+func usageSchema() {
+	fmt.Println("")
+	fmt.Println("Usage:")
+	fmt.Println("")
+	fmt.Println(color("emits schema output", Cyan, true), color("[argument]", Magenta, true))
+	fmt.Println("")
+	fmt.Println("Prints the json schema of the emitted files; schema version", data.SchemaVersion)
+	fmt.Println("")
+	fmt.Println("The argument is:")
+	fmt.Println("")
+	fmt.Println(argument("type", fmt.Sprintf("schema type; %s", strings.Join(data.Schemas(), ", ")), Magenta))
+	fmt.Println("")
+}
@@ -1,20 +1,28 @@
 package command
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/emits-io/emits/configuration"
+	"github.com/emits-io/emits/data"
 )
 
-// Index struct
+// Index struct lists the task index files and the bundles emitted with them.
 type Index struct {
-	File []string `json:"file"`
+	File   []string `json:"file"`
+	Bundle []string `json:"bundle,omitempty"`
 }
 
 func parseServe() (err error) {
@@ -38,32 +46,45 @@ func parseServe() (err error) {
 		return fmt.Errorf(color("task or group argument is required\n", Red, false))
 	}
 
-	config, err := configuration.Open()
+	config, err := openConfiguration()
 	if err != nil {
 		return err
 	}
 
-	var tasks []string
+	index := Index{}
+	served := map[string]bool{}
+	handle := func(directory string) {
+		if served[directory] {
+			return
+		}
+		served[directory] = true
+		http.Handle("/"+directory+"/", AllowHandler())
+		index.File = append(index.File, path.Join(directory, runIndex))
+		if bundle := servedBundle(directory); len(bundle) > 0 {
+			index.Bundle = append(index.Bundle, bundle)
+		}
+	}
 
 	if len(groupName) > 0 {
-		if !config.HasGroup(configuration.Group{Name: groupName}) {
-			return fmt.Errorf(fmt.Sprintf("%s %s", color(groupName, Red, false), "is not a valid group"))
+		names, err := runnableGroup(config, groupName)
+		if err != nil {
+			return err
 		}
-		for _, t := range config.GetGroup(configuration.Group{Name: groupName}).Tasks {
-			task, err := serve(config, t)
+		for _, t := range names {
+			directory, err := servable(config, t)
 			if err == nil {
-				tasks = append(tasks, fmt.Sprintf("%s%s%s%s%s", emits, string(os.PathSeparator), task, string(os.PathSeparator), emits+".json"))
+				handle(directory)
 			}
 		}
 	} else if len(taskName) > 0 {
-		task, err := serve(config, taskName)
+		directory, err := servable(config, taskName)
 		if err != nil {
 			return err
 		}
-		tasks = append(tasks, fmt.Sprintf("%s%s%s%s%s", emits, string(os.PathSeparator), task, string(os.PathSeparator), emits+".json"))
+		handle(directory)
 	}
 
-	http.Handle("/", IndexHandler(Index{File: tasks}))
+	http.Handle("/", IndexHandler(index))
 	fmt.Println("")
 	fmt.Println(fmt.Sprintf("%s:%v", color("http://localhost", Cyan, true), color(fmt.Sprintf("%v", *portFlag), Cyan, true)))
 	fmt.Println("")
@@ -72,13 +93,25 @@ func parseServe() (err error) {
 	return nil
 }
 
-func serve(config configuration.File, name string) (taskName string, err error) {
-	if !config.HasTask(configuration.Task{Name: name}) {
-		return "", fmt.Errorf(fmt.Sprintf("%s %s", color(name, Red, false), "is not a valid task"))
+// servable returns the slash separated path of the task output directory relative to the configuration directory; tasks sharing an output directory share its handler.
+func servable(config configuration.File, name string) (directory string, err error) {
+	task, err := runnableTask(config, name)
+	if err != nil {
+		return "", err
+	}
+	directory, err = filepath.Abs(task.OutputDirectory())
+	if err != nil {
+		return "", err
+	}
+	current, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	directory, err = filepath.Rel(current, directory)
+	if err != nil || directory == "." || directory == ".." || strings.HasPrefix(directory, ".."+string(os.PathSeparator)) {
+		return "", fmt.Errorf(fmt.Sprintf("%s %s", color(task.OutputDirectory(), Red, false), "output directory must be within the configuration directory to be served"))
 	}
-	task := config.GetTask(configuration.Task{Name: name})
-	http.Handle(fmt.Sprintf("%s%s%s%s%s", string(os.PathSeparator), emits, string(os.PathSeparator), task.Name, string(os.PathSeparator)), AllowHandler())
-	return name, nil
+	return filepath.ToSlash(directory), nil
 }
 
 func printExit(line string, prefixSpace bool) {
@@ -95,13 +128,26 @@ func printExit(line string, prefixSpace bool) {
 	fmt.Println("Exit this utility to stop the server...")
 }
 
-// AllowHandler is a restrictive http handler that only serves .json files from a relative emits directory.
+// AllowHandler is a restrictive http handler that only serves emitted format files from a relative task output directory; an id query parameter serves the matching node of a .json file and a gzip compressed bundle is served at the bundle file path.
 func AllowHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Add("Content-Type", "application/json")
 		w.Header().Add("Access-Control-Allow-Origin", "*")
-		var p = fmt.Sprintf(".%s", filepath.Clean(r.URL.String()))
-		if jsonExists(p) {
+		var p = fmt.Sprintf(".%s", filepath.Clean(r.URL.Path))
+		contentType, ok := data.ContentType(p)
+		if !ok {
+			http.Error(w, "", 500)
+			return
+		}
+		w.Header().Add("Content-Type", contentType)
+		if strings.HasPrefix(filepath.Base(p), data.BundleName+".") && !fileExists(p) && fileExists(p+data.CompressionExtension) {
+			serveCompressed(w, r, p+data.CompressionExtension)
+			return
+		}
+		if fileExists(p) {
+			if id := r.URL.Query().Get("id"); len(id) > 0 && jsonExists(p) {
+				serveNode(w, p, id)
+				return
+			}
 			http.ServeFile(w, r, p)
 			return
 		}
@@ -110,6 +156,88 @@ func AllowHandler() http.Handler {
 	})
 }
 
+// serveCompressed serves a gzip compressed file; it is decompressed for clients that do not accept the gzip encoding.
+func serveCompressed(w http.ResponseWriter, r *http.Request, name string) {
+	w.Header().Add("Vary", "Accept-Encoding")
+	if acceptsGzip(r) {
+		w.Header().Add("Content-Encoding", "gzip")
+		http.ServeFile(w, r, name)
+		return
+	}
+	file, err := os.Open(name)
+	if err != nil {
+		http.Error(w, "", 500)
+		return
+	}
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		http.Error(w, "", 500)
+		return
+	}
+	defer reader.Close()
+	io.Copy(w, reader)
+}
+
+// acceptsGzip returns true if the request accepts the gzip content encoding.
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		fields := strings.Split(encoding, ";")
+		if name := strings.ToLower(strings.TrimSpace(fields[0])); name != "gzip" && name != "*" {
+			continue
+		}
+		accepted := true
+		for _, parameter := range fields[1:] {
+			if q := strings.Replace(parameter, " ", "", -1); strings.HasPrefix(q, "q=") {
+				value, err := strconv.ParseFloat(q[2:], 64)
+				accepted = err == nil && value > 0
+			}
+		}
+		if accepted {
+			return true
+		}
+	}
+	return false
+}
+
+// servedBundle returns the slash separated path the bundle listed by the task index is served at; an empty path is returned without a bundle.
+func servedBundle(directory string) string {
+	read, err := ioutil.ReadFile(filepath.Join(filepath.FromSlash(directory), runIndex))
+	if err != nil {
+		return ""
+	}
+	index := configuration.Index{}
+	if json.Unmarshal(read, &index) != nil || len(index.Bundle) == 0 {
+		return ""
+	}
+	return strings.TrimSuffix(filepath.ToSlash(index.Bundle), data.CompressionExtension)
+}
+
+// serveNode writes the node with a matching identifier from an emitted file.
+func serveNode(w http.ResponseWriter, name string, id string) {
+	read, err := ioutil.ReadFile(name)
+	if err != nil {
+		http.Error(w, "", 500)
+		return
+	}
+	node, err := data.Identified(read, id)
+	if err != nil {
+		http.Error(w, "", 500)
+		return
+	}
+	if node == nil {
+		http.Error(w, "", 404)
+		return
+	}
+	var output bytes.Buffer
+	err = json.Indent(&output, node, "", "\t")
+	if err != nil {
+		http.Error(w, "", 500)
+		return
+	}
+	w.Write(output.Bytes())
+}
+
 // IndexHandler func
 func IndexHandler(index Index) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -130,15 +258,19 @@ func IndexHandler(index Index) http.Handler {
 
 func jsonExists(name string) bool {
 	if strings.HasSuffix(name, ".json") {
-		file, err := os.Stat(name)
-		if os.IsNotExist(err) {
-			return false
-		}
-		return !file.IsDir()
+		return fileExists(name)
 	}
 	return false
 }
 
+func fileExists(name string) bool {
+	file, err := os.Stat(name)
+	if os.IsNotExist(err) {
+		return false
+	}
+	return !file.IsDir()
+}
+
 func usageServe() {
 	fmt.Println("")
 	fmt.Println("Usage:")
@@ -6,9 +6,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/emits-io/emits/configuration"
+	"github.com/emits-io/emits/data"
 )
 
 func parseUpdate() (err error) {
@@ -30,6 +32,16 @@ func parseUpdate() (err error) {
 	commentBlockCloseFlag := flagSet.String("comment-block-close", "", "")
 	commentInlineFlag := flagSet.String("comment-inline", "", "")
 	sourceFlag := flagSet.String("source", "", "")
+	splitFlag := flagSet.String("split", "", "")
+	modeFlag := flagSet.String("mode", "", "")
+	arrayFlag := flagSet.String("array", "", "")
+	formatFlag := flagSet.String("format", "", "")
+	timestampSourceFlag := flagSet.String("timestamp-source", "", "")
+	timestampFormatFlag := flagSet.String("timestamp-format", "", "")
+	deterministicFlag := flagSet.String("deterministic", "", "")
+	ignoreFlag := flagSet.String("ignore", "", "")
+	outputDirectoryFlag := flagSet.String("output-directory", "", "")
+	outputPathFlag := flagSet.String("output-path", "", "")
 	//
 	flagSet.Usage = func() {
 		usageUpdate()
@@ -44,7 +56,7 @@ func parseUpdate() (err error) {
 		return fmt.Errorf(color("task argument is required\n", Red, false))
 	}
 
-	config, err := configuration.Open()
+	config, err := openConfiguration()
 	if err != nil {
 		return err
 	}
@@ -119,7 +131,87 @@ func parseUpdate() (err error) {
 
 	source := strings.ToLower(strings.TrimSpace(*sourceFlag))
 	if len(source) > 0 && source == "true" || len(source) > 0 && source == "false" {
-		task.Source = source == "true"
+		enabled := source == "true"
+		task.Source = &enabled
+	}
+
+	split := strings.TrimSpace(*splitFlag)
+	if len(split) > 0 {
+		task.Split = split
+	}
+
+	mode := strings.ToLower(strings.TrimSpace(*modeFlag))
+	if len(mode) > 0 {
+		if mode != configuration.ModeNode && mode != configuration.ModeObject {
+			return fmt.Errorf(fmt.Sprintf("%s %s", color(mode, Red, false), "is not a valid mode"))
+		}
+		task.Mode = mode
+	}
+
+	array := strings.TrimSpace(*arrayFlag)
+	if len(array) > 0 {
+		task.Array = strings.Split(array, " ")
+	}
+
+	format := strings.ToLower(strings.TrimSpace(*formatFlag))
+	if len(format) > 0 {
+		_, err := data.NewEncoder(format)
+		if err != nil {
+			return fmt.Errorf(fmt.Sprintf("%s %s", color(format, Red, false), "is not a valid format"))
+		}
+		task.Format = format
+	}
+
+	timestampSource := strings.ToLower(strings.TrimSpace(*timestampSourceFlag))
+	timestampFormat := strings.ToLower(strings.TrimSpace(*timestampFormatFlag))
+	if len(timestampSource) > 0 || len(timestampFormat) > 0 {
+		if task.Timestamp == nil {
+			task.Timestamp = &configuration.Timestamp{}
+		}
+		if len(timestampSource) > 0 {
+			if timestampSource != configuration.TimestampNow && timestampSource != configuration.TimestampNone && timestampSource != configuration.TimestampSource {
+				return fmt.Errorf(fmt.Sprintf("%s %s", color(timestampSource, Red, false), "is not a valid timestamp source"))
+			}
+			task.Timestamp.Source = timestampSource
+		}
+		if len(timestampFormat) > 0 {
+			if timestampFormat != configuration.TimestampString && timestampFormat != configuration.TimestampRFC3339 {
+				return fmt.Errorf(fmt.Sprintf("%s %s", color(timestampFormat, Red, false), "is not a valid timestamp format"))
+			}
+			task.Timestamp.Format = timestampFormat
+		}
+	}
+
+	deterministic := strings.ToLower(strings.TrimSpace(*deterministicFlag))
+	if len(deterministic) > 0 && deterministic == "true" || len(deterministic) > 0 && deterministic == "false" {
+		enabled := deterministic == "true"
+		task.Deterministic = &enabled
+	}
+
+	ignore := strings.ToLower(strings.TrimSpace(*ignoreFlag))
+	if len(ignore) > 0 && ignore == "true" || len(ignore) > 0 && ignore == "false" {
+		enabled := ignore == "true"
+		task.Ignore = &enabled
+	}
+
+	outputDirectory := strings.TrimSpace(*outputDirectoryFlag)
+	outputPath := strings.TrimSpace(*outputPathFlag)
+	if len(outputDirectory) > 0 || len(outputPath) > 0 {
+		if task.Output == nil {
+			task.Output = &configuration.Output{}
+		}
+		if len(outputDirectory) > 0 {
+			task.Output.Directory = filepath.ToSlash(outputDirectory)
+		}
+		if len(outputPath) > 0 {
+			task.Output.Path = outputPath
+		}
+		check := configuration.File{Tasks: []configuration.Task{task}}
+		for _, p := range check.Validate() {
+			if strings.HasPrefix(p.Path, "task[0].output") {
+				return fmt.Errorf(fmt.Sprintf("%s %s", color(p.Path, Red, false), p.Message))
+			}
+		}
 	}
 
 	task = task.Sanitize()
@@ -181,5 +273,15 @@ func usageUpdate() {
 	fmt.Println(argument("comment-block-close", "comment block close", Magenta))
 	fmt.Println(argument("comment-inline", "comment inline", Magenta))
 	fmt.Println(argument("source", "allow source", Magenta))
+	fmt.Println(argument("split", "top-level keyword that starts a new document", Magenta))
+	fmt.Println(argument("mode", "output shape; node or object", Magenta))
+	fmt.Println(argument("array", "keywords always output as arrays in object mode", Magenta))
+	fmt.Println(argument("format", "output format; json, yaml or toml", Magenta))
+	fmt.Println(argument("timestamp-source", "file timestamp; now, none or source", Magenta))
+	fmt.Println(argument("timestamp-format", "file timestamp format; string or rfc3339", Magenta))
+	fmt.Println(argument("deterministic", "sort index entries and omit the timestamp", Magenta))
+	fmt.Println(argument("ignore", "skip files ignored by .gitignore, .git/info/exclude and .emitsignore", Magenta))
+	fmt.Println(argument("output-directory", "output directory; {task} is the task name; defaults to emits/{task}", Magenta))
+	fmt.Println(argument("output-path", "output file path template, flatten or hash; {path}, {dir}, {name}, {ext}, {document}, {hash}, {flat} and {format}", Magenta))
 	fmt.Println("")
 }
@@ -0,0 +1,49 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/emits-io/emits/configuration"
+)
+
+func parseValidate() (err error) {
+	helpFlag := flag.Bool("h", false, "")
+	flagSet := flag.NewFlagSet("validate", flag.ExitOnError)
+	flagSet.Usage = func() {
+		usageValidate()
+	}
+	flagSet.BoolVar(helpFlag, "h", false, "")
+	flagSet.BoolVar(helpFlag, "help", false, "")
+	flagSet.Parse(os.Args[2:])
+
+	config, err := openConfiguration()
+	if err != nil {
+		return err
+	}
+
+	problems := config.Validate()
+
+	fmt.Println("")
+	if len(problems) == 0 {
+		fmt.Println(configuration.Path(), color("is valid", Green, true))
+		fmt.Println("")
+		return nil
+	}
+	for _, p := range problems {
+		fmt.Println(fmt.Sprintf("%s: %s", color(p.Path, Red, true), p.Message))
+	}
+	fmt.Println("")
+	return fmt.Errorf(color(fmt.Sprintf("%s has %v problems", configuration.Path(), len(problems)), Red, false))
+}
+
+func usageValidate() {
+	fmt.Println("")
+	fmt.Println("Usage:")
+	fmt.Println("")
+	fmt.Println(color("emits validate", Cyan, true))
+	fmt.Println("")
+	fmt.Println("Checks the configuration file and prints each problem with its json path; the exit code is non-zero when a problem is found")
+	fmt.Println("")
+}
@@ -0,0 +1,119 @@
+package configuration
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// environmentVariable matches ${NAME} and ${NAME:-default}; placeholders of other names, such as ${file.path}, are document variables.
+var environmentVariable = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expand returns the value with the environment variables replaced and the names of the undefined variables without a default.
+//
+// The default is used when the variable is undefined or empty.
+func expand(value string) (expanded string, undefined []string) {
+	expanded = environmentVariable.ReplaceAllStringFunc(value, func(match string) string {
+		m := environmentVariable.FindStringSubmatch(match)
+		if v, ok := os.LookupEnv(m[1]); ok && (len(v) > 0 || len(m[2]) == 0) {
+			return v
+		}
+		if len(m[2]) > 0 {
+			return m[3]
+		}
+		undefined = append(undefined, m[1])
+		return match
+	})
+	return expanded, undefined
+}
+
+// interpolate (recursive) replaces the environment variables within the string fields of the value; task variables are document variables and are not replaced.
+func interpolate(v reflect.Value, path string) (problems []Problem) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			return interpolate(v.Elem(), path)
+		}
+	case reflect.String:
+		value, undefined := expand(v.String())
+		for _, name := range undefined {
+			problems = append(problems, Problem{Path: path, Message: fmt.Sprintf("%s environment variable is not defined", name)})
+		}
+		v.SetString(value)
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			problems = append(problems, interpolate(v.Index(i), fmt.Sprintf("%s[%v]", path, i))...)
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(k))
+			problems = append(problems, interpolate(value, fmt.Sprintf("%s.%v", path, k))...)
+			v.SetMapIndex(k, value)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			tag := strings.Split(field.Tag.Get("json"), ",")
+			if len(field.PkgPath) > 0 || tag[0] == "-" || v.Type() == reflect.TypeOf(Task{}) && tag[0] == "variables" {
+				continue
+			}
+			name := strings.TrimPrefix(path+"."+tag[0], ".")
+			problems = append(problems, interpolate(v.Field(i), name)...)
+		}
+	}
+	return problems
+}
+
+// interpolateTask returns a copy of the task with the environment variables replaced; the task is not changed.
+func interpolateTask(task Task) (Task, []Problem) {
+	var interpolated Task
+	read, err := json.Marshal(task)
+	if err == nil {
+		err = json.Unmarshal(read, &interpolated)
+	}
+	if err != nil {
+		return task, []Problem{{Message: err.Error()}}
+	}
+	return interpolated, interpolate(reflect.ValueOf(&interpolated), "")
+}
+
+// Resolved returns a copy of the configuration with every task resolved and the environment variables of the groups replaced; the configuration is not changed.
+func (f *File) Resolved() (resolved File, err error) {
+	for _, t := range f.Tasks {
+		task, err := f.Resolve(t)
+		if err != nil {
+			return resolved, err
+		}
+		resolved.Tasks = append(resolved.Tasks, task)
+	}
+	for i, g := range f.Groups {
+		group, problems := interpolateGroup(g, fmt.Sprintf("group[%v]", i))
+		if len(problems) > 0 {
+			return resolved, fmt.Errorf("%s", problems[0].String())
+		}
+		resolved.Groups = append(resolved.Groups, group)
+	}
+	return resolved, nil
+}
+
+// ResolveGroup returns a copy of the group with the environment variables of its task names replaced; the group is not changed.
+func (f *File) ResolveGroup(group Group) (resolved Group, err error) {
+	if !f.HasGroup(group) {
+		return resolved, fmt.Errorf("%s group does not exist", group.Name)
+	}
+	resolved, problems := interpolateGroup(f.GetGroup(group), "group")
+	if len(problems) > 0 {
+		return resolved, fmt.Errorf("%s group: %s", group.Name, problems[0].String())
+	}
+	return resolved, nil
+}
+
+// interpolateGroup returns a copy of the group with the environment variables replaced.
+func interpolateGroup(group Group, path string) (Group, []Problem) {
+	interpolated := Group{Name: group.Name, Tasks: append([]string{}, group.Tasks...)}
+	return interpolated, interpolate(reflect.ValueOf(&interpolated), path)
+}
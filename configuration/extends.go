@@ -0,0 +1,116 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Resolve returns a copy of the task with the fields of the tasks it extends merged in and the environment variables of its string fields replaced; the nearest task takes precedence.
+//
+// Strings, comments, flags and the render, generate, timestamp and output options of a task replace the inherited values when set, so a task may set the node mode or a false flag over an inherited value.
+// Variables are merged by name and patterns, arrays and environment names are appended to the inherited values; a negated !pattern include removes the files of an inherited include pattern.
+func (f *File) Resolve(task Task) (resolved Task, err error) {
+	resolved, err = f.extend(task)
+	if err != nil {
+		return resolved, err
+	}
+	resolved, problems := interpolateTask(resolved)
+	if len(problems) > 0 {
+		var messages []string
+		for _, p := range problems {
+			messages = append(messages, p.String())
+		}
+		return resolved, fmt.Errorf("%s task: %s", task.Name, strings.Join(messages, "; "))
+	}
+	return resolved, nil
+}
+
+// extend returns the task with the fields of the tasks it extends merged in.
+func (f *File) extend(task Task) (resolved Task, err error) {
+	if !f.HasTask(task) {
+		return resolved, fmt.Errorf("%s task does not exist", task.Name)
+	}
+	lineage := []Task{f.GetTask(task)}
+	chain := []string{lineage[0].Name}
+	for current := lineage[0]; len(strings.TrimSpace(current.Extends)) > 0; {
+		parent := Task{Name: current.Extends}
+		for _, name := range chain {
+			if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(parent.Name)) {
+				return resolved, fmt.Errorf("%s task extends itself; %s extends %s", lineage[0].Name, strings.Join(chain, " extends "), parent.Name)
+			}
+		}
+		if !f.HasTask(parent) {
+			return resolved, fmt.Errorf("%s task extends %s which does not exist", current.Name, parent.Name)
+		}
+		current = f.GetTask(parent)
+		lineage = append(lineage, current)
+		chain = append(chain, current.Name)
+	}
+	resolved = lineage[len(lineage)-1]
+	for i := len(lineage) - 2; i >= 0; i-- {
+		resolved = inherit(resolved, lineage[i])
+	}
+	resolved.Abstract = lineage[0].Abstract
+	return resolved.Sanitize(), nil
+}
+
+// inherit returns the task with the unset fields of the parent task merged in.
+func inherit(parent Task, task Task) Task {
+	inheritString := func(value *string, inherited string) {
+		if len(*value) == 0 {
+			*value = inherited
+		}
+	}
+	inheritString(&task.Description, parent.Description)
+	inheritString(&task.Split, parent.Split)
+	inheritString(&task.Mode, parent.Mode)
+	inheritString(&task.Format, parent.Format)
+	inheritString(&task.Comment.Inline, parent.Comment.Inline)
+	if len(task.Comment.Block.Open) == 0 && len(task.Comment.Block.Line) == 0 && len(task.Comment.Block.Close) == 0 {
+		task.Comment.Block = parent.Comment.Block
+	}
+	inheritFlag := func(value **bool, inherited *bool) {
+		if *value == nil {
+			*value = inherited
+		}
+	}
+	inheritFlag(&task.Source, parent.Source)
+	inheritFlag(&task.Deterministic, parent.Deterministic)
+	inheritFlag(&task.Ignore, parent.Ignore)
+	task.File = inheritPattern(parent.File, task.File)
+	task.Keyword = inheritPattern(parent.Keyword, task.Keyword)
+	task.Configuration = inheritPattern(parent.Configuration, task.Configuration)
+	task.Array = append(append([]string{}, parent.Array...), task.Array...)
+	task.Environment = append(append([]string{}, parent.Environment...), task.Environment...)
+	if len(parent.Variables) > 0 {
+		variables := map[string]string{}
+		for k, v := range parent.Variables {
+			variables[k] = v
+		}
+		for k, v := range task.Variables {
+			variables[k] = v
+		}
+		task.Variables = variables
+	}
+	if task.Render == nil {
+		task.Render = parent.Render
+	}
+	if task.Generate == nil {
+		task.Generate = parent.Generate
+	}
+	if task.Timestamp == nil {
+		task.Timestamp = parent.Timestamp
+	}
+	if task.Output == nil {
+		task.Output = parent.Output
+	}
+	return task
+}
+
+// inheritPattern returns the parent patterns followed by the patterns of the task.
+func inheritPattern(parent Pattern, pattern Pattern) Pattern {
+	return Pattern{
+		Include: append(append([]string{}, parent.Include...), pattern.Include...),
+		Exclude: append(append([]string{}, parent.Exclude...), pattern.Exclude...),
+	}
+}
@@ -1,6 +1,7 @@
 package configuration
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -10,29 +11,69 @@ import (
 )
 
 const (
+	// name of the configuration file written when none is found
 	name = "emits.json"
+	// ModeNode task mode writes the generic keyword, value and data node shape; it is the default mode.
+	ModeNode = "node"
+	// ModeObject task mode writes keywords as object keys.
+	ModeObject = "object"
 )
 
+// filePath of the configuration file read and written by Open and Write
+var filePath = name
+
+// formats of the registered output encoders; a task format must be one of them once any is registered.
+var formats = map[string]bool{}
+
+// RegisterFormat adds an output format a task may use; the data package registers the format of every encoder.
+func RegisterFormat(format string) {
+	formats[strings.ToLower(format)] = true
+}
+
 // File struct
 type File struct {
-	Groups []Group `json:"group,omitempty"`
-	Tasks  []Task  `json:"task,omitempty"`
+	Groups      []Group `json:"group,omitempty"`
+	Tasks       []Task  `json:"task,omitempty"`
+	raw         []byte
+	sanitized   []string
+	unsanitized []Task
 }
 
 // Index struct
 type Index struct {
-	Files []string `json:"file"`
+	SchemaVersion string   `json:"schemaVersion"`
+	Files         []string `json:"file"`
+	Bundle        string   `json:"bundle,omitempty"`
 }
 
 // Task struct
 type Task struct {
-	Name          string  `json:"name"`
-	Description   string  `json:"description"`
-	Comment       Comment `json:"comment"`
-	Source        bool    `json:"source"`
-	File          Pattern `json:"file"`
-	Keyword       Pattern `json:"keyword"`
-	Configuration Pattern `json:"configuration"`
+	Name          string            `json:"name"`
+	Extends       string            `json:"extends,omitempty"`
+	Abstract      bool              `json:"abstract,omitempty"`
+	Description   string            `json:"description"`
+	Comment       Comment           `json:"comment"`
+	Source        *bool             `json:"source,omitempty"`
+	File          Pattern           `json:"file"`
+	Keyword       Pattern           `json:"keyword"`
+	Configuration Pattern           `json:"configuration"`
+	Split         string            `json:"split,omitempty"`
+	Mode          string            `json:"mode,omitempty"`
+	Array         []string          `json:"array,omitempty"`
+	Format        string            `json:"format,omitempty"`
+	Render        *Render           `json:"render,omitempty"`
+	Generate      *Generate         `json:"generate,omitempty"`
+	Timestamp     *Timestamp        `json:"timestamp,omitempty"`
+	Deterministic *bool             `json:"deterministic,omitempty"`
+	Ignore        *bool             `json:"ignore,omitempty"`
+	Output        *Output           `json:"output,omitempty"`
+	Variables     map[string]string `json:"variables,omitempty"`
+	Environment   []string          `json:"environment,omitempty"`
+}
+
+// Enabled returns true if the flag is set to true; an unset flag is inherited from the extended task and is false otherwise.
+func Enabled(flag *bool) bool {
+	return flag != nil && *flag
 }
 
 // Group struct
@@ -46,9 +87,33 @@ func (t *Task) Sanitize() Task {
 	t.File = t.File.santize()
 	t.Keyword = t.Keyword.santize()
 	t.Configuration = t.Configuration.santize()
+	t.Environment = deduplicate(t.Environment)
+	t.Split = strings.TrimSpace(t.Split)
+	t.Mode = strings.ToLower(strings.TrimSpace(t.Mode))
+	t.Array = deduplicate(t.Array)
+	t.Format = strings.ToLower(strings.TrimSpace(t.Format))
 	return *t
 }
 
+// Timestamp struct; source is now (default), none or source and format is string (default) or rfc3339.
+type Timestamp struct {
+	Source string `json:"source,omitempty"`
+	Format string `json:"format,omitempty"`
+}
+
+const (
+	// TimestampNow source stamps the time the file was emitted; it is the default source.
+	TimestampNow = "now"
+	// TimestampNone source does not stamp a time.
+	TimestampNone = "none"
+	// TimestampSource source stamps SOURCE_DATE_EPOCH when set; otherwise the source file modification time.
+	TimestampSource = "source"
+	// TimestampString format uses the go time string format; it is the default format.
+	TimestampString = "string"
+	// TimestampRFC3339 format uses the RFC 3339 format.
+	TimestampRFC3339 = "rfc3339"
+)
+
 // Comment struct
 type Comment struct {
 	Block  Block  `json:"block,omitempty"`
@@ -75,6 +140,9 @@ func (p *Pattern) santize() Pattern {
 }
 
 func deduplicate(values []string) (santized []string) {
+	if values != nil {
+		santized = []string{}
+	}
 	for _, v := range values {
 		value := strings.TrimSpace(v)
 		if len(value) > 0 {
@@ -93,58 +161,97 @@ func deduplicate(values []string) (santized []string) {
 	return santized
 }
 
-// Open func
+// Find returns the path of the nearest configuration file walking up from the directory; the configuration path of the directory is returned when none is found.
+func Find(directory string) (string, error) {
+	directory, err := filepath.Abs(directory)
+	if err != nil {
+		return "", err
+	}
+	for current := directory; ; current = filepath.Dir(current) {
+		if file, ok := Lookup(current); ok {
+			return file, nil
+		}
+		if filepath.Dir(current) == current {
+			return filepath.Join(directory, name), nil
+		}
+	}
+}
+
+// Lookup returns the path of the configuration file within the directory; emits.json takes precedence over emits.yaml, emits.yml and emits.toml.
+func Lookup(directory string) (string, bool) {
+	for _, n := range names {
+		file := filepath.Join(directory, n)
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			return file, true
+		}
+	}
+	return filepath.Join(directory, name), false
+}
+
+// Use sets the path of the configuration file read and written by Open and Write.
+func Use(file string) {
+	filePath = file
+}
+
+// Open reads the configuration file without writing it; a missing file is an empty configuration.
 func Open() (file File, err error) {
 	err = file.unmarshal()
+	if err != nil {
+		return file, err
+	}
 
 	// Santize
+	file.unsanitized = append([]Task(nil), file.Tasks...)
 	for i, t := range file.Tasks {
+		before, _ := json.Marshal(t)
 		file.Tasks[i] = t.Sanitize()
+		after, _ := json.Marshal(file.Tasks[i])
+		if !bytes.Equal(before, after) {
+			file.sanitized = append(file.sanitized, t.Name)
+		}
 	}
 
-	err = file.Write()
+	return file, nil
+}
+
+// Path returns the path of the configuration file.
+func Path() string {
+	return filePath
+}
 
-	return file, err
+// Sanitized returns the names of the tasks that sanitization changed; the changes are saved by the next write.
+func (f *File) Sanitized() []string {
+	return f.sanitized
 }
 
 // Unmarshal func
 func (f *File) unmarshal() (err error) {
 
 	if !f.exists() {
-		err := f.initialize()
-		if err != nil {
-			return err
-		}
+		return nil
 	}
 
-	open, err := os.Open(name)
+	read, err := ioutil.ReadFile(filePath)
 	if err != nil {
 		return err
 	}
 
-	read, err := ioutil.ReadAll(open)
+	decoded, err := syntaxOf(filePath).decode(read)
 	if err != nil {
 		return err
 	}
-
-	err = json.Unmarshal(read, &f)
+	err = json.Unmarshal(decoded, &f)
 	if err != nil {
 		return err
 	}
+	f.raw = read
 
 	return nil
 }
 
-func (f *File) initialize() (err error) {
-	if _, err := os.Stat(name); os.IsNotExist(err) {
-		return f.Write()
-	}
-	return nil
-}
-
-// Exists returns a bool based on emits.json existing or not.
+// exists returns true if the configuration file path is a file; the path is an emits.json, emits.yaml, emits.yml or emits.toml file.
 func (f *File) exists() bool {
-	info, err := os.Stat(name)
+	info, err := os.Stat(filePath)
 	if os.IsNotExist(err) {
 		return false
 	}
@@ -222,91 +329,77 @@ func (f *File) UpdateTask(task Task) (err error) {
 	return f.Write()
 }
 
+// CreateGroup creates a group
+func (f *File) CreateGroup(group Group) (err error) {
+	if !f.HasGroup(group) {
+		f.Groups = append(f.Groups, group)
+		return nil
+	}
+	return fmt.Errorf("%s group already exists; cannot create group", group.Name)
+}
+
+// DeleteGroup deletes a group by name
+func (f *File) DeleteGroup(group Group) (success bool) {
+	for i, g := range f.Groups {
+		if strings.EqualFold(strings.TrimSpace(g.Name), strings.TrimSpace(group.Name)) {
+			f.Groups = append(f.Groups[:i], f.Groups[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateGroup updates a group by name
+func (f *File) UpdateGroup(group Group) (err error) {
+	for i, g := range f.Groups {
+		if strings.EqualFold(strings.TrimSpace(g.Name), strings.TrimSpace(group.Name)) {
+			f.Groups[i] = group
+			break
+		}
+	}
+	return f.Write()
+}
+
 // ListTasks lists tasks and desriptions
 func (f *File) ListTasks() {
 
 }
 
-// Write file
+// Write the configuration file in the syntax of its extension; the keys unknown to the configuration are kept, empty keys are only written when the file that was read has them and json and yaml files keep its key order.
 func (f *File) Write() (err error) {
-	file, err := json.MarshalIndent(f, "", "\t")
+	updated, err := json.Marshal(f)
 	if err != nil {
 		return err
 	}
-	err = ioutil.WriteFile(name, file, 0644)
+	current, err := decodeOrdered(updated)
 	if err != nil {
 		return err
 	}
+	file, err := syntaxOf(filePath).encode(f.raw, current)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(filePath, file, 0644)
+	if err != nil {
+		return err
+	}
+	f.raw = file
+	f.sanitized = nil
+	f.unsanitized = nil
 	return nil
 }
 
-// Files file
+// Files returns the files matched by the task file include and exclude patterns; ignored files are not matched when the task honors ignore files.
 func (t *Task) Files() (matches []string, err error) {
-	// Includes
-	var includePattern []string
-	var includes []string
-	for _, pattern := range t.File.Include {
-		includePatternChecked := false
-		for i := range includePattern {
-			if includePattern[i] == pattern {
-				includePatternChecked = true
-				break
-			}
-		}
-		if includePatternChecked == false {
-			includePattern = append(includePattern, pattern)
-			include, err := filepath.Glob(pattern)
-			if err != nil {
-				return nil, err
-			}
-			for _, name := range include {
-				includes = append(includes, name)
-			}
-		}
-	}
-	includes = uniqueFile(includes)
-	// Excludes
-	var excludePattern []string
-	var excludes []string
-	for _, pattern := range t.File.Exclude {
-		excludePatternChecked := false
-		for i := range excludePattern {
-			if excludePattern[i] == pattern {
-				excludePatternChecked = true
-				break
-			}
-		}
-		if excludePatternChecked == false {
-			excludePattern = append(excludePattern, pattern)
-			exclude, err := filepath.Glob(pattern)
-			if err != nil {
-				return nil, err
-			}
-			for _, name := range exclude {
-				excludes = append(excludes, name)
-			}
-		}
-	}
-	excludes = uniqueFile(excludes)
-	// Includes - Excludes
-	for _, exclude := range excludes {
-		for i, include := range includes {
-			if exclude == include {
-				includes = append(includes[:i], includes[i+1:]...)
-			}
-		}
+	matcher, err := NewMatcher(t.File.Include, t.File.Exclude)
+	if err != nil {
+		return nil, err
 	}
-	return includes, nil
-}
-
-func uniqueFile(slice []string) []string {
-	keys := make(map[string]bool)
-	list := []string{}
-	for _, entry := range slice {
-		if _, value := keys[entry]; !value {
-			keys[entry] = true
-			list = append(list, entry)
+	if Enabled(t.Ignore) {
+		err = matcher.Ignore()
+		if err != nil {
+			return nil, err
 		}
 	}
-	return list
+	return matcher.Walk()
 }
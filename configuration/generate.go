@@ -0,0 +1,27 @@
+package configuration
+
+// Generate struct
+type Generate struct {
+	Go []GoMapping `json:"go,omitempty"`
+}
+
+// GoMapping struct selects the nodes with a keyword and generates a go declaration of a kind; const, map or struct.
+//
+// The key and value of a node are read from the child nodes with the key and value keywords; otherwise from the first word and the remainder of the node value.
+type GoMapping struct {
+	Keyword string `json:"keyword"`
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Type    string `json:"type,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Value   string `json:"value,omitempty"`
+}
+
+const (
+	// GoConst kind generates a constant per node prefixed by the mapping name; a mapping type declares a named constant type.
+	GoConst = "const"
+	// GoMap kind generates a map[string]string variable of the node keys and values.
+	GoMap = "map"
+	// GoStruct kind generates a struct type with a field per child keyword and a slice variable with an element per node.
+	GoStruct = "struct"
+)
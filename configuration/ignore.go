@@ -0,0 +1,211 @@
+package configuration
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFiles are read in every walked directory of a task that honors ignore files; later files take precedence.
+var IgnoreFiles = []string{".gitignore", ".emitsignore"}
+
+const (
+	// ignoreExclude file of the repository read before the ignore files
+	ignoreExclude = ".git/info/exclude"
+	// ignoreGit directory is never walked by a task that honors ignore files
+	ignoreGit = ".git"
+)
+
+// ignoreRule structure is a compiled gitignore pattern of an ignore file within the base directory.
+type ignoreRule struct {
+	rule
+	base      string
+	directory bool
+}
+
+// ignore structure holds the rules of the ignore files read during a walk; the last rule matching a path decides.
+//
+// The prefix is the slash separated working directory relative to the repository work tree; rule bases are relative to the work tree.
+type ignore struct {
+	rules   []ignoreRule
+	visited map[string]bool
+	prefix  string
+}
+
+// read appends the rules of the ignore file; paths of the rules are relative to the base directory and a missing file has no rules.
+func (i *ignore) read(file string, base string) error {
+	open, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer open.Close()
+	scanner := bufio.NewScanner(open)
+	for scanner.Scan() {
+		r, ok := ignoreLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		r.base = base
+		i.rules = append(i.rules, r)
+	}
+	return scanner.Err()
+}
+
+// ignoreLine returns the rule of a gitignore line; blank lines and comments have no rule.
+//
+// A pattern without a slash matches at any depth, a pattern with a slash is relative to the ignore file and a trailing slash only matches directories.
+func ignoreLine(line string) (r ignoreRule, ok bool) {
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = line[:len(line)-1]
+	}
+	if len(line) == 0 || strings.HasPrefix(line, "#") {
+		return r, false
+	}
+	if strings.HasPrefix(line, matchNegate) {
+		r.negate = true
+		line = line[len(matchNegate):]
+	} else if strings.HasPrefix(line, "\\!") || strings.HasPrefix(line, "\\#") {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		r.directory = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	if !strings.Contains(line, "/") {
+		line = "**/" + line
+	}
+	line = strings.TrimPrefix(line, "/")
+	if len(line) == 0 {
+		return r, false
+	}
+	expr, err := compileGlob(line)
+	if err != nil {
+		return r, false
+	}
+	r.pattern, r.expr = line, expr
+	return r, true
+}
+
+// ignored returns true if the slash separated path relative to the working directory is ignored; paths outside the work tree are never ignored.
+func (i *ignore) ignored(name string, directory bool) (ignored bool) {
+	name = path.Join(i.prefix, name)
+	if name == ".." || strings.HasPrefix(name, "../") {
+		return false
+	}
+	for _, r := range i.rules {
+		if r.directory && !directory {
+			continue
+		}
+		relative := name
+		if len(r.base) > 0 {
+			if !strings.HasPrefix(name, r.base+"/") {
+				continue
+			}
+			relative = name[len(r.base)+1:]
+		}
+		if r.expr.MatchString(relative) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// enter visits the directories from the working directory down to a walk root and returns true if the root or a directory containing it is ignored.
+func (i *ignore) enter(root string) (bool, error) {
+	if path.IsAbs(root) || root == ".." || strings.HasPrefix(root, "../") {
+		return false, nil
+	}
+	ignored, err := i.visit(".")
+	directory := "."
+	for _, segment := range strings.Split(root, "/") {
+		if err != nil || ignored {
+			return ignored, err
+		}
+		directory = path.Join(directory, segment)
+		ignored, err = i.visit(directory)
+	}
+	return ignored, err
+}
+
+// visit reads the ignore files of a walked directory once and returns true if the directory is ignored.
+func (i *ignore) visit(directory string) (bool, error) {
+	if path.Base(directory) == ignoreGit {
+		return true, nil
+	}
+	if directory != "." && i.ignored(directory, true) {
+		return true, nil
+	}
+	if i.visited[directory] {
+		return false, nil
+	}
+	i.visited[directory] = true
+	return false, i.readAll(filepath.FromSlash(directory), path.Join(i.prefix, directory))
+}
+
+// readAll appends the rules of the ignore files of the directory; the base is the slash separated directory relative to the work tree.
+func (i *ignore) readAll(directory string, base string) error {
+	if base == "." {
+		base = ""
+	}
+	for _, name := range IgnoreFiles {
+		err := i.read(filepath.Join(directory, name), base)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Ignore makes the walk honor the repository exclude file and the ignore files of the walked directories with gitignore semantics.
+//
+// The ignore files of the directories from the repository work tree down to the working directory apply to every walk.
+func (m *Matcher) Ignore() error {
+	m.ignore = &ignore{visited: map[string]bool{}}
+	current, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	tree := workTree(current)
+	prefix, err := filepath.Rel(tree, current)
+	if err != nil {
+		return err
+	}
+	if prefix = filepath.ToSlash(prefix); prefix != "." {
+		m.ignore.prefix = prefix
+	}
+	if info, err := os.Stat(filepath.Join(tree, ignoreGit)); err == nil && info.IsDir() {
+		err = m.ignore.read(filepath.Join(tree, filepath.FromSlash(ignoreExclude)), "")
+		if err != nil {
+			return err
+		}
+	}
+	if len(m.ignore.prefix) == 0 {
+		return nil
+	}
+	directory, base := tree, ""
+	for _, segment := range strings.Split(m.ignore.prefix, "/") {
+		err = m.ignore.readAll(directory, base)
+		if err != nil {
+			return err
+		}
+		directory, base = filepath.Join(directory, segment), path.Join(base, segment)
+	}
+	return nil
+}
+
+// workTree returns the nearest directory containing .git walking up from the directory; the directory itself without a repository.
+func workTree(directory string) string {
+	for parent := directory; ; parent = filepath.Dir(parent) {
+		if _, err := os.Stat(filepath.Join(parent, ignoreGit)); err == nil {
+			return parent
+		}
+		if filepath.Dir(parent) == parent {
+			return directory
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package configuration
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// matchNegate prefix of a pattern that reverses the result of the previous patterns for the paths it matches
+	matchNegate = "!"
+	// matchMeta characters of a pattern that do not match themselves
+	matchMeta = "*?[{\\"
+)
+
+// rule structure is a compiled pattern; the segments are the compiled directory segments of the pattern up to a ** segment, which is nil.
+type rule struct {
+	pattern  string
+	negate   bool
+	expr     *regexp.Regexp
+	segments []*regexp.Regexp
+}
+
+// Matcher structure tests slash separated paths against compiled include and exclude patterns.
+//
+// Patterns support * and ? within a path segment, ** across segments, [...] character classes and {a,b} alternatives; a pattern starting with ! negates the patterns before it.
+type Matcher struct {
+	include []rule
+	exclude []rule
+	ignore  *ignore
+}
+
+// NewMatcher returns the matcher of the include and exclude patterns.
+func NewMatcher(include []string, exclude []string) (m *Matcher, err error) {
+	m = &Matcher{}
+	m.include, err = compileRules(include)
+	if err != nil {
+		return nil, err
+	}
+	m.exclude, err = compileRules(exclude)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// compileRules returns the rules of the patterns in order with their alternatives expanded.
+func compileRules(patterns []string) (rules []rule, err error) {
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		negate := strings.HasPrefix(p, matchNegate)
+		if negate {
+			p = p[len(matchNegate):]
+		}
+		p = strings.TrimPrefix(filepath.ToSlash(p), "./")
+		if len(p) == 0 {
+			continue
+		}
+		for _, e := range expandBraces(p) {
+			expr, err := compileGlob(e)
+			if err != nil {
+				return nil, fmt.Errorf("%s is not a valid pattern: %s", p, err.Error())
+			}
+			segments, err := compileSegments(e)
+			if err != nil {
+				return nil, fmt.Errorf("%s is not a valid pattern: %s", p, err.Error())
+			}
+			rules = append(rules, rule{pattern: e, negate: negate, expr: expr, segments: segments})
+		}
+	}
+	return rules, nil
+}
+
+// expandBraces (recursive) returns the patterns of the {a,b} alternatives; braces without a closing brace are kept.
+func expandBraces(pattern string) []string {
+	open := -1
+	depth := 0
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '{':
+			if depth == 0 {
+				open = i
+			}
+			depth++
+		case '}':
+			if depth == 0 {
+				continue
+			}
+			depth--
+			if depth > 0 {
+				continue
+			}
+			var expanded []string
+			for _, alternative := range splitAlternatives(pattern[open+1 : i]) {
+				expanded = append(expanded, expandBraces(pattern[:open]+alternative+pattern[i+1:])...)
+			}
+			return expanded
+		}
+	}
+	return []string{pattern}
+}
+
+// splitAlternatives returns the comma separated alternatives that are not within nested braces.
+func splitAlternatives(alternatives string) (split []string) {
+	depth := 0
+	start := 0
+	for i := 0; i < len(alternatives); i++ {
+		switch alternatives[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				split = append(split, alternatives[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(split, alternatives[start:])
+}
+
+// compileGlob returns the regular expression of a glob pattern without alternatives.
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' && (i == 0 || pattern[i-1] == '/') {
+				end := i + 2
+				if end == len(pattern) {
+					expr.WriteString(".*")
+				} else if pattern[end] == '/' {
+					expr.WriteString("(?:.*/)?")
+					end++
+				} else {
+					expr.WriteString("[^/]*")
+				}
+				i = end - 1
+				continue
+			}
+			expr.WriteString("[^/]*")
+		case '?':
+			expr.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed character class")
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + strings.Replace(class, "\\", "\\\\", -1) + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				c = pattern[i]
+			}
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.Compile(expr.String())
+}
+
+// compileSegments returns the regular expressions of the directory segments of a glob pattern up to and including the first ** segment, which is nil.
+func compileSegments(pattern string) (segments []*regexp.Regexp, err error) {
+	split := strings.Split(pattern, "/")
+	for _, s := range split[:len(split)-1] {
+		if s == "**" {
+			return append(segments, nil), nil
+		}
+		expr, err := compileGlob(s)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, expr)
+	}
+	if split[len(split)-1] == "**" {
+		segments = append(segments, nil)
+	}
+	return segments, nil
+}
+
+// reaches returns true if a path within the slash separated directory can match the rule.
+func (r rule) reaches(directory string) bool {
+	for i, d := range strings.Split(directory, "/") {
+		if i >= len(r.segments) {
+			return false
+		}
+		if r.segments[i] == nil {
+			return true
+		}
+		if !r.segments[i].MatchString(d) {
+			return false
+		}
+	}
+	return true
+}
+
+// reachable returns true if an include pattern can match a path within the directory.
+func (m *Matcher) reachable(directory string) bool {
+	for _, r := range m.include {
+		if !r.negate && r.reaches(directory) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns whether the rules select the path; the last rule matching the path decides.
+func matches(rules []rule, name string) (matched bool) {
+	for _, r := range rules {
+		if r.expr.MatchString(name) {
+			matched = !r.negate
+		}
+	}
+	return matched
+}
+
+// Match returns true if the slash separated path is included and not excluded.
+func (m *Matcher) Match(name string) bool {
+	name = strings.TrimPrefix(path.Clean(filepath.ToSlash(name)), "./")
+	return matches(m.include, name) && !matches(m.exclude, name)
+}
+
+// excluded returns true if every path within the directory is excluded; directories are not skipped when an exclude pattern is negated.
+func (m *Matcher) excluded(directory string) bool {
+	for _, r := range m.exclude {
+		if r.negate {
+			return false
+		}
+	}
+	return matches(m.exclude, directory+"/\x00") && matches(m.exclude, directory+"/\x00/\x00")
+}
+
+// roots returns the directories to walk; the static directory prefixes of the include patterns without the directories within another root.
+func (m *Matcher) roots() (roots []string) {
+	for _, r := range m.include {
+		if r.negate {
+			continue
+		}
+		root := "."
+		segments := strings.Split(r.pattern, "/")
+		for i, s := range segments[:len(segments)-1] {
+			if strings.ContainsAny(s, matchMeta) {
+				break
+			}
+			root = path.Join(segments[:i+1]...)
+		}
+		if strings.HasPrefix(r.pattern, "/") {
+			root = "/" + strings.TrimPrefix(root, "/")
+		}
+		roots = append(roots, root)
+	}
+	sort.Strings(roots)
+	var unique []string
+	for _, root := range roots {
+		within := false
+		for _, u := range unique {
+			within = within || root == u || u == "." && !strings.HasPrefix(root, "../") && root != ".." && !path.IsAbs(root) || strings.HasPrefix(root, strings.TrimSuffix(u, "/")+"/")
+		}
+		if !within {
+			unique = append(unique, root)
+		}
+	}
+	return unique
+}
+
+// Walk returns the files matched within a single walk of each root directory in lexical order.
+//
+// Directories no include pattern can reach are not walked and unreadable files and directories within a root are skipped.
+func (m *Matcher) Walk() (files []string, err error) {
+	for _, root := range m.roots() {
+		if _, err := os.Stat(root); os.IsNotExist(err) {
+			continue
+		}
+		if m.ignore != nil {
+			ignored, err := m.ignore.enter(root)
+			if err != nil {
+				return nil, err
+			}
+			if ignored {
+				continue
+			}
+		}
+		err = filepath.Walk(root, func(name string, info os.FileInfo, err error) error {
+			if err != nil && name != root {
+				if info != nil && info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			slash := strings.TrimPrefix(filepath.ToSlash(name), "./")
+			if info.IsDir() {
+				if name != root && (m.excluded(slash) || !m.reachable(slash)) {
+					return filepath.SkipDir
+				}
+				if m.ignore != nil {
+					ignored, err := m.ignore.visit(slash)
+					if err != nil {
+						return err
+					}
+					if ignored {
+						return filepath.SkipDir
+					}
+				}
+				return nil
+			}
+			if m.ignore != nil && m.ignore.ignored(slash, false) {
+				return nil
+			}
+			if m.Match(slash) {
+				files = append(files, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,222 @@
+package configuration
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// member structure is a key value of an ordered json object.
+type member struct {
+	key   string
+	value interface{}
+}
+
+// ordered structure is a json object that keeps the order of its keys.
+type ordered []member
+
+// get returns the value of the key.
+func (o ordered) get(key string) (interface{}, bool) {
+	for _, m := range o {
+		if m.key == key {
+			return m.value, true
+		}
+	}
+	return nil, false
+}
+
+// MarshalJSON writes the object keys in order.
+func (o ordered) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("{")
+	for i, m := range o {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(key)
+		buffer.WriteString(":")
+		buffer.Write(value)
+	}
+	buffer.WriteString("}")
+	return buffer.Bytes(), nil
+}
+
+// decodeOrdered returns the json value with objects decoded as ordered objects and numbers kept as written.
+func decodeOrdered(read []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(read))
+	decoder.UseNumber()
+	return decodeValue(decoder)
+}
+
+// decodeValue (recursive) returns the next json value of the decoder.
+func decodeValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	delimiter, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+	switch delimiter {
+	case '{':
+		object := ordered{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, member{key: key.(string), value: value})
+		}
+		_, err = decoder.Token()
+		return object, err
+	case '[':
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err = decoder.Token()
+		return array, err
+	}
+	return nil, nil
+}
+
+// jsonFields returns the json names and types of the structure fields.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		if len(field.PkgPath) > 0 || tag[0] == "-" {
+			continue
+		}
+		if len(tag[0]) == 0 {
+			tag[0] = field.Name
+		}
+		fields[tag[0]] = field.Type
+	}
+	return fields
+}
+
+// merge (recursive) returns the updated value of the type written over the original value; the original key order and keys unknown to the type are kept and empty keys absent from the original are not added.
+//
+// Array items of a structure with a name are matched by name; other structure items are matched by position.
+func merge(original interface{}, updated interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		o, _ := original.(ordered)
+		u, ok := updated.(ordered)
+		if !ok {
+			return updated
+		}
+		known := jsonFields(t)
+		merged := ordered{}
+		for _, m := range o {
+			field, ok := known[m.key]
+			if !ok {
+				merged = append(merged, m)
+				continue
+			}
+			if value, ok := u.get(m.key); ok {
+				merged = append(merged, member{key: m.key, value: merge(m.value, value, field)})
+			}
+		}
+		for _, m := range u {
+			if _, ok := o.get(m.key); ok {
+				continue
+			}
+			if field, ok := known[m.key]; ok {
+				m.value = merge(nil, m.value, field)
+			}
+			if !empty(m.value) {
+				merged = append(merged, m)
+			}
+		}
+		return merged
+	case reflect.Slice:
+		o, _ := original.([]interface{})
+		u, ok := updated.([]interface{})
+		element := t.Elem()
+		for element.Kind() == reflect.Ptr {
+			element = element.Elem()
+		}
+		if !ok || element.Kind() != reflect.Struct {
+			return updated
+		}
+		_, named := jsonFields(element)["name"]
+		merged := []interface{}{}
+		for i, value := range u {
+			var match interface{}
+			if named {
+				match = namedItem(o, value)
+			} else if i < len(o) {
+				match = o[i]
+			}
+			merged = append(merged, merge(match, value, element))
+		}
+		return merged
+	}
+	return updated
+}
+
+// empty (recursive) returns true for null, empty strings, empty arrays and objects of empty values; false and zero are values.
+func empty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	case ordered:
+		for _, m := range v {
+			if !empty(m.value) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// namedItem returns the item of the array with the same name as the value; names are not case sensitive.
+func namedItem(items []interface{}, value interface{}) interface{} {
+	object, ok := value.(ordered)
+	if !ok {
+		return nil
+	}
+	name, _ := object.get("name")
+	for _, item := range items {
+		if o, ok := item.(ordered); ok {
+			if n, _ := o.get("name"); strings.EqualFold(toString(n), toString(name)) {
+				return item
+			}
+		}
+	}
+	return nil
+}
+
+func toString(value interface{}) string {
+	s, _ := value.(string)
+	return strings.TrimSpace(s)
+}
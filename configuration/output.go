@@ -0,0 +1,134 @@
+package configuration
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Output struct sets the task output directory and the path template of the emitted files within it.
+//
+// The directory may contain {task}; the path is a template or the flatten or hash preset.
+type Output struct {
+	Directory string `json:"directory,omitempty"`
+	Path      string `json:"path,omitempty"`
+}
+
+const (
+	// OutputFlatten path preset writes every file into the output directory; directory separators become underscores.
+	OutputFlatten = "flatten"
+	// OutputHash path preset names every file by a hash of its source path.
+	OutputHash = "hash"
+	// outputDirectory template of the default output directory
+	outputDirectory = "emits/{task}"
+	// outputPath template of the default path; the source path followed by the format extension
+	outputPath = "{path}.{format}"
+)
+
+var (
+	outputPresets = map[string]string{
+		OutputFlatten: "{flat}.{format}",
+		OutputHash:    "{hash}.{format}",
+	}
+	// OutputPlaceholders of the path template; the source path, directory, name without extension, extension, split document name, path hash, flattened path and format extension.
+	OutputPlaceholders = []string{"path", "dir", "name", "ext", "document", "hash", "flat", "format"}
+	outputPlaceholder  = regexp.MustCompile(`{([a-z]+)}`)
+)
+
+// OutputDirectory returns the output directory of the task; emits/<task> is the default directory.
+func (t Task) OutputDirectory() string {
+	directory := outputDirectory
+	if t.Output != nil && len(strings.TrimSpace(t.Output.Directory)) > 0 {
+		directory = strings.TrimSpace(t.Output.Directory)
+	}
+	return filepath.Clean(filepath.FromSlash(strings.Replace(directory, "{task}", t.Name, -1)))
+}
+
+// OutputFile returns the path of an emitted file within the output directory of the task from the source path, the split document name and the format extension.
+//
+// Paths never leave the output directory; leading parent directories of the source path are removed.
+func (t Task) OutputFile(source string, document string, extension string) string {
+	template := outputPath
+	if t.Output != nil && len(strings.TrimSpace(t.Output.Path)) > 0 {
+		template = strings.TrimSpace(t.Output.Path)
+	}
+	if preset, ok := outputPresets[strings.ToLower(template)]; ok {
+		template = preset
+	}
+	source = strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(source)), "/")
+	key := source
+	if len(document) > 0 {
+		key += "." + document
+	}
+	dir := path.Dir(source)
+	if dir == "." {
+		dir = ""
+	}
+	ext := path.Ext(source)
+	hash := sha1.Sum([]byte(key))
+	values := map[string]string{
+		"path":     key,
+		"dir":      dir,
+		"name":     strings.TrimSuffix(path.Base(source), ext),
+		"ext":      strings.TrimPrefix(ext, "."),
+		"document": document,
+		"hash":     hex.EncodeToString(hash[:])[:12],
+		"flat":     strings.Replace(key, "/", "_", -1),
+		"format":   strings.TrimPrefix(extension, "."),
+	}
+	file := outputPlaceholder.ReplaceAllStringFunc(template, func(match string) string {
+		if value, ok := values[match[1:len(match)-1]]; ok {
+			return value
+		}
+		return match
+	})
+	return filepath.FromSlash(strings.TrimPrefix(path.Clean("/"+file), "/"))
+}
+
+// outputRoot returns the first include root of the task that is the output directory or is within it.
+func (t Task) outputRoot() (root string, ok bool) {
+	matcher, err := NewMatcher(t.File.Include, nil)
+	if err != nil {
+		return "", false
+	}
+	directory, err := filepath.Abs(t.OutputDirectory())
+	if err != nil {
+		return "", false
+	}
+	for _, root := range matcher.roots() {
+		absolute, err := filepath.Abs(filepath.FromSlash(root))
+		if err != nil {
+			continue
+		}
+		relative, err := filepath.Rel(directory, absolute)
+		if err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+			return root, true
+		}
+	}
+	return "", false
+}
+
+// validate returns the problems of the output directory and path template.
+func (o Output) validate(path string) (problems []Problem) {
+	if filepath.Clean(strings.TrimSpace(o.Directory)) == "." && len(strings.TrimSpace(o.Directory)) > 0 {
+		problems = append(problems, Problem{Path: path + ".directory", Message: "directory cannot be the configuration directory"})
+	}
+	template := strings.TrimSpace(o.Path)
+	if _, ok := outputPresets[strings.ToLower(template)]; ok {
+		return problems
+	}
+	for _, m := range outputPlaceholder.FindAllStringSubmatch(template, -1) {
+		known := false
+		for _, p := range OutputPlaceholders {
+			known = known || p == m[1]
+		}
+		if !known {
+			problems = append(problems, Problem{Path: path + ".path", Message: fmt.Sprintf("{%s} is not a valid placeholder; %s", m[1], strings.Join(OutputPlaceholders, ", "))})
+		}
+	}
+	return problems
+}
@@ -0,0 +1,86 @@
+package configuration
+
+// Render struct
+type Render struct {
+	Markdown *Markdown  `json:"markdown,omitempty"`
+	Template []Template `json:"template,omitempty"`
+	Site     *Site      `json:"site,omitempty"`
+	OpenAPI  *OpenAPI   `json:"openapi,omitempty"`
+	Man      *Man       `json:"man,omitempty"`
+}
+
+// Man struct; section defaults to 1 and keyword maps the page sections to annotation keywords.
+type Man struct {
+	Section string     `json:"section,omitempty"`
+	Keyword ManKeyword `json:"keyword,omitempty"`
+}
+
+// ManKeyword struct; empty fields use the field name as the keyword.
+type ManKeyword struct {
+	Command     string `json:"command,omitempty"`
+	Synopsis    string `json:"synopsis,omitempty"`
+	Description string `json:"description,omitempty"`
+	Option      string `json:"option,omitempty"`
+	Example     string `json:"example,omitempty"`
+}
+
+// OpenAPI struct; keyword maps the operation fields to annotation keywords.
+type OpenAPI struct {
+	Title   string         `json:"title,omitempty"`
+	Version string         `json:"version,omitempty"`
+	Keyword OpenAPIKeyword `json:"keyword,omitempty"`
+}
+
+// OpenAPIKeyword struct; empty fields use the field name as the keyword.
+type OpenAPIKeyword struct {
+	Route       string `json:"route,omitempty"`
+	Method      string `json:"method,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	Param       string `json:"param,omitempty"`
+	Response    string `json:"response,omitempty"`
+}
+
+// Site struct; theme is a directory of files overriding the embedded layout.html, home.html, document.html, keyword.html, style.css and search.js theme files.
+type Site struct {
+	Title string `json:"title,omitempty"`
+	Theme string `json:"theme,omitempty"`
+}
+
+// Markdown struct maps keywords to a markdown style; heading, list, paragraph, code or hidden.
+type Markdown struct {
+	Keyword map[string]string `json:"keyword,omitempty"`
+}
+
+// Template struct names a go template file; the output name is rendered per file, with {path} replaced by the document path, or once per task.
+type Template struct {
+	File   string `json:"file"`
+	Engine string `json:"engine,omitempty"`
+	Scope  string `json:"scope,omitempty"`
+	Output string `json:"output"`
+}
+
+const (
+	// TemplateText engine renders with text/template; it is the default engine.
+	TemplateText = "text"
+	// TemplateHTML engine renders with html/template.
+	TemplateHTML = "html"
+	// TemplateFile scope renders the template once per document; it is the default scope.
+	TemplateFile = "file"
+	// TemplateTask scope renders the template once per task.
+	TemplateTask = "task"
+)
+
+const (
+	// MarkdownHeading style renders the node value as a heading.
+	MarkdownHeading = "heading"
+	// MarkdownList style renders the node as a list item with the keyword in bold.
+	MarkdownList = "list"
+	// MarkdownParagraph style renders the node value as a paragraph.
+	MarkdownParagraph = "paragraph"
+	// MarkdownCode style renders the node value as a code block.
+	MarkdownCode = "code"
+	// MarkdownHidden style does not render the node or its children.
+	MarkdownHidden = "hidden"
+)
@@ -0,0 +1,214 @@
+package configuration
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/emits-io/emits/internal/markup"
+	"gopkg.in/yaml.v3"
+)
+
+// names of the configuration files in discovery precedence within a directory
+var names = []string{"emits.json", "emits.yaml", "emits.yml", "emits.toml"}
+
+// syntax interface reads and writes a configuration file format through json.
+type syntax interface {
+	// decode returns the json of the configuration file.
+	decode(read []byte) ([]byte, error)
+	// encode returns the configuration file of the updated value written over the original file.
+	encode(original []byte, updated interface{}) ([]byte, error)
+}
+
+var syntaxes = map[string]syntax{
+	".json": jsonSyntax{},
+	".yaml": yamlSyntax{},
+	".yml":  yamlSyntax{},
+	".toml": tomlSyntax{},
+}
+
+// syntaxOf returns the syntax of the configuration file extension; json is the default syntax.
+func syntaxOf(file string) syntax {
+	if s, ok := syntaxes[strings.ToLower(filepath.Ext(file))]; ok {
+		return s
+	}
+	return jsonSyntax{}
+}
+
+// jsonSyntax keeps the key order and the keys unknown to the configuration.
+type jsonSyntax struct{}
+
+func (jsonSyntax) decode(read []byte) ([]byte, error) {
+	return read, nil
+}
+
+func (jsonSyntax) encode(original []byte, updated interface{}) ([]byte, error) {
+	if len(original) > 0 {
+		o, err := decodeOrdered(original)
+		if err != nil {
+			return nil, err
+		}
+		updated = merge(o, updated, reflect.TypeOf(File{}))
+	}
+	return json.MarshalIndent(updated, "", "\t")
+}
+
+// yamlSyntax keeps the key order, the keys unknown to the configuration and the comments and styles of the nodes that are not changed.
+type yamlSyntax struct{}
+
+func (yamlSyntax) decode(read []byte) ([]byte, error) {
+	var value interface{}
+	err := yaml.Unmarshal(read, &value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+func (yamlSyntax) encode(original []byte, updated interface{}) ([]byte, error) {
+	var document yaml.Node
+	if len(original) > 0 {
+		err := yaml.Unmarshal(original, &document)
+		if err != nil {
+			return nil, err
+		}
+		updated = merge(yamlOrdered(&document), updated, reflect.TypeOf(File{}))
+	}
+	node, err := markup.YAMLNode(updated)
+	if err != nil {
+		return nil, err
+	}
+	if document.Kind == yaml.DocumentNode && len(document.Content) > 0 {
+		syncYAML(document.Content[0], node.Content[0])
+	} else {
+		document = *node
+	}
+	return markup.EncodeYAML(&document)
+}
+
+// yamlOrdered (recursive) returns the value of the yaml node with mappings as ordered objects.
+func yamlOrdered(node *yaml.Node) interface{} {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return yamlOrdered(node.Content[0])
+	case yaml.AliasNode:
+		return yamlOrdered(node.Alias)
+	case yaml.MappingNode:
+		object := ordered{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			object = append(object, member{key: node.Content[i].Value, value: yamlOrdered(node.Content[i+1])})
+		}
+		return object
+	case yaml.SequenceNode:
+		array := []interface{}{}
+		for _, c := range node.Content {
+			array = append(array, yamlOrdered(c))
+		}
+		return array
+	}
+	var value interface{}
+	node.Decode(&value)
+	return value
+}
+
+// syncYAML (recursive) writes the updated node over the original node; unchanged original nodes are kept with their comments and styles.
+//
+// Sequence items with a name are matched by name; other items are matched by position.
+func syncYAML(original *yaml.Node, updated *yaml.Node) {
+	if original.Kind != updated.Kind {
+		head, line, foot := original.HeadComment, original.LineComment, original.FootComment
+		*original = *updated
+		original.HeadComment, original.LineComment, original.FootComment = head, line, foot
+		return
+	}
+	switch original.Kind {
+	case yaml.MappingNode:
+		var content []*yaml.Node
+		for i := 0; i+1 < len(updated.Content); i += 2 {
+			key, value := updated.Content[i], updated.Content[i+1]
+			if j := yamlKey(original, key.Value); j >= 0 {
+				syncYAML(original.Content[j+1], value)
+				content = append(content, original.Content[j], original.Content[j+1])
+			} else {
+				content = append(content, key, value)
+			}
+		}
+		original.Content = content
+	case yaml.SequenceNode:
+		var content []*yaml.Node
+		for i, item := range updated.Content {
+			if match := yamlItem(original, item, i); match != nil {
+				syncYAML(match, item)
+				content = append(content, match)
+			} else {
+				content = append(content, item)
+			}
+		}
+		original.Content = content
+	case yaml.ScalarNode:
+		if original.ShortTag() == updated.ShortTag() && (original.Value == updated.Value || original.ShortTag() == "!!null") {
+			return
+		}
+		original.Value, original.Tag, original.Style = updated.Value, updated.Tag, updated.Style
+	}
+}
+
+// yamlKey returns the content index of the mapping key; -1 is returned when the key does not exist.
+func yamlKey(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// yamlItem returns the original sequence item matching the updated item.
+func yamlItem(sequence *yaml.Node, item *yaml.Node, index int) *yaml.Node {
+	if item.Kind == yaml.MappingNode {
+		if i := yamlKey(item, "name"); i >= 0 {
+			for _, o := range sequence.Content {
+				if j := yamlKey(o, "name"); o.Kind == yaml.MappingNode && j >= 0 && strings.EqualFold(strings.TrimSpace(o.Content[j+1].Value), strings.TrimSpace(item.Content[i+1].Value)) {
+					return o
+				}
+			}
+			return nil
+		}
+	}
+	if index < len(sequence.Content) {
+		return sequence.Content[index]
+	}
+	return nil
+}
+
+// tomlSyntax keeps the keys unknown to the configuration; comments and key order are not kept.
+type tomlSyntax struct{}
+
+func (tomlSyntax) decode(read []byte) ([]byte, error) {
+	var value map[string]interface{}
+	err := toml.Unmarshal(read, &value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+func (tomlSyntax) encode(original []byte, updated interface{}) ([]byte, error) {
+	if len(original) > 0 {
+		read, err := tomlSyntax{}.decode(original)
+		if err != nil {
+			return nil, err
+		}
+		o, err := decodeOrdered(read)
+		if err != nil {
+			return nil, err
+		}
+		updated = merge(o, updated, reflect.TypeOf(File{}))
+	}
+	return markup.EncodeTOML(updated)
+}
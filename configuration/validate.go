@@ -0,0 +1,205 @@
+package configuration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Problem struct is a semantic error of the configuration file at a json path.
+type Problem struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// String returns the path and message of the problem.
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Path, p.Message)
+}
+
+// Validate returns the semantic problems of the configuration; the paths are the json paths of the fields, such as task[2].file.include.
+//
+// Tasks are validated as they were read, before sanitization, so the paths index the file.
+func (f *File) Validate() (problems []Problem) {
+	report := func(path string, format string, a ...interface{}) {
+		problems = append(problems, Problem{Path: path, Message: fmt.Sprintf(format, a...)})
+	}
+	read := f.Tasks
+	if f.unsanitized != nil {
+		read = f.unsanitized
+	}
+	tasks := map[string]int{}
+	outputs := map[string]int{}
+	for i, t := range read {
+		path := fmt.Sprintf("task[%v]", i)
+		problems = append(problems, t.File.validate(path+".file")...)
+		problems = append(problems, t.Keyword.validate(path+".keyword")...)
+		problems = append(problems, t.Configuration.validate(path+".configuration")...)
+		problems = append(problems, validateValues(path+".array", t.Array)...)
+		problems = append(problems, validateValues(path+".environment", t.Environment)...)
+		t = t.Sanitize()
+		key := strings.ToLower(strings.TrimSpace(t.Name))
+		if len(key) == 0 {
+			report(path+".name", "name is required")
+		} else if j, ok := tasks[key]; ok {
+			report(path+".name", "%s duplicates the task[%v].name %s; task names are not case sensitive", t.Name, j, read[j].Name)
+		} else {
+			tasks[key] = i
+		}
+		resolved, err := f.extend(t)
+		if err != nil {
+			report(path+".extends", "%s", err.Error())
+			continue
+		}
+		resolved, undefined := interpolateTask(resolved)
+		for _, p := range undefined {
+			report(path+"."+p.Path, "%s", p.Message)
+		}
+		problems = append(problems, resolved.validate(path)...)
+		if resolved.Abstract {
+			continue
+		}
+		directory := resolved.OutputDirectory()
+		if j, ok := outputs[directory]; ok {
+			report(path+".output.directory", "%s output directory is also the output directory of task[%v] %s", directory, j, read[j].Name)
+		} else {
+			outputs[directory] = i
+		}
+	}
+	groups := map[string]int{}
+	for i, g := range f.Groups {
+		path := fmt.Sprintf("group[%v]", i)
+		key := strings.ToLower(strings.TrimSpace(g.Name))
+		if len(key) == 0 {
+			report(path+".name", "name is required")
+		} else if j, ok := groups[key]; ok {
+			report(path+".name", "%s duplicates the group[%v].name %s; group names are not case sensitive", g.Name, j, f.Groups[j].Name)
+		} else {
+			groups[key] = i
+		}
+		if len(g.Tasks) == 0 {
+			report(path+".tasks", "at least one task is required")
+		}
+		resolved, undefined := interpolateGroup(g, path)
+		skip := map[string]bool{}
+		for _, p := range undefined {
+			report(p.Path, "%s", p.Message)
+			skip[p.Path] = true
+		}
+		for k, name := range resolved.Tasks {
+			if skip[fmt.Sprintf("%s.tasks[%v]", path, k)] {
+				continue
+			}
+			if !f.HasTask(Task{Name: name}) {
+				report(fmt.Sprintf("%s.tasks[%v]", path, k), "%s task does not exist", name)
+			} else if f.GetTask(Task{Name: name}).Abstract {
+				report(fmt.Sprintf("%s.tasks[%v]", path, k), "%s task is abstract and cannot be run", name)
+			}
+		}
+	}
+	return problems
+}
+
+// validate returns the empty and duplicate include and exclude patterns.
+func (p Pattern) validate(path string) (problems []Problem) {
+	problems = append(problems, validateValues(path+".include", p.Include)...)
+	return append(problems, validateValues(path+".exclude", p.Exclude)...)
+}
+
+// validateValues returns the empty and duplicate values of a list as they were read; sanitization removes them.
+func validateValues(path string, values []string) (problems []Problem) {
+	seen := map[string]int{}
+	for k, v := range values {
+		value := strings.TrimSpace(v)
+		field := fmt.Sprintf("%s[%v]", path, k)
+		if len(value) == 0 {
+			problems = append(problems, Problem{Path: field, Message: "value is empty"})
+		} else if j, ok := seen[value]; ok {
+			problems = append(problems, Problem{Path: field, Message: fmt.Sprintf("%s duplicates %s[%v]", value, path, j)})
+		} else {
+			seen[value] = k
+		}
+	}
+	return problems
+}
+
+// validate returns the semantic problems of the resolved task fields; abstract tasks do not require include patterns or comments.
+func (t Task) validate(path string) (problems []Problem) {
+	report := func(field string, format string, a ...interface{}) {
+		problems = append(problems, Problem{Path: path + "." + field, Message: fmt.Sprintf(format, a...)})
+	}
+	if len(t.File.Include) == 0 && !t.Abstract {
+		report("file.include", "at least one include pattern is required")
+	}
+	block := t.Comment.Block
+	if len(block.Open) > 0 && len(block.Close) == 0 {
+		report("comment.block.close", "block close is required when the block open is %s", block.Open)
+	}
+	if len(block.Close) > 0 && len(block.Open) == 0 {
+		report("comment.block.open", "block open is required when the block close is %s", block.Close)
+	}
+	if len(block.Line) > 0 && len(block.Open) == 0 {
+		report("comment.block.line", "block line requires a block open and close")
+	}
+	if len(block.Open) == 0 && len(block.Close) == 0 && len(t.Comment.Inline) == 0 && !t.Abstract {
+		report("comment", "a block or inline comment is required")
+	}
+	if len(t.Mode) > 0 && t.Mode != ModeNode && t.Mode != ModeObject {
+		report("mode", "%s is not a valid mode; %s or %s", t.Mode, ModeNode, ModeObject)
+	}
+	if _, ok := formats[t.Format]; len(t.Format) > 0 && len(formats) > 0 && !ok {
+		var names []string
+		for f := range formats {
+			names = append(names, f)
+		}
+		sort.Strings(names)
+		report("format", "%s is not a valid format; %s", t.Format, strings.Join(names, ", "))
+	}
+	if t.Timestamp != nil {
+		switch t.Timestamp.Source {
+		case "", TimestampNow, TimestampNone, TimestampSource:
+		default:
+			report("timestamp.source", "%s is not a valid timestamp source; %s, %s or %s", t.Timestamp.Source, TimestampNow, TimestampNone, TimestampSource)
+		}
+		switch t.Timestamp.Format {
+		case "", TimestampString, TimestampRFC3339:
+		default:
+			report("timestamp.format", "%s is not a valid timestamp format; %s or %s", t.Timestamp.Format, TimestampString, TimestampRFC3339)
+		}
+	}
+	if t.Output != nil {
+		problems = append(problems, t.Output.validate(path+".output")...)
+	}
+	if root, ok := t.outputRoot(); ok && !t.Abstract {
+		report("output.directory", "%s output directory contains the include root %s; emitted files would be written among the source files", t.OutputDirectory(), root)
+	}
+	if t.Render != nil {
+		for k, template := range t.Render.Template {
+			field := fmt.Sprintf("render.template[%v]", k)
+			if len(template.File) == 0 {
+				report(field+".file", "template file is required")
+			}
+			if len(template.Output) == 0 {
+				report(field+".output", "template output is required")
+			}
+			if len(template.Engine) > 0 && template.Engine != TemplateText && template.Engine != TemplateHTML {
+				report(field+".engine", "%s is not a valid engine; %s or %s", template.Engine, TemplateText, TemplateHTML)
+			}
+			if len(template.Scope) > 0 && template.Scope != TemplateFile && template.Scope != TemplateTask {
+				report(field+".scope", "%s is not a valid scope; %s or %s", template.Scope, TemplateFile, TemplateTask)
+			}
+		}
+	}
+	if t.Generate != nil {
+		for k, m := range t.Generate.Go {
+			field := fmt.Sprintf("generate.go[%v]", k)
+			if len(m.Keyword) == 0 {
+				report(field+".keyword", "keyword is required")
+			}
+			if m.Kind != GoConst && m.Kind != GoMap && m.Kind != GoStruct {
+				report(field+".kind", "%s is not a valid kind; %s, %s or %s", m.Kind, GoConst, GoMap, GoStruct)
+			}
+		}
+	}
+	return problems
+}
@@ -0,0 +1,71 @@
+package data
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/emits-io/emits/configuration"
+)
+
+const (
+	// BundleName of the aggregated task document written within the task output directory; the format extension is appended.
+	BundleName = "bundle"
+	// CompressionExtension appended to the bundle file name when it is gzip compressed
+	CompressionExtension = ".gz"
+)
+
+// Bundle structure aggregates every document of a task into a single file keyed by source path.
+type Bundle struct {
+	SchemaVersion string                 `json:"schemaVersion"`
+	Files         map[string]interface{} `json:"file"`
+}
+
+// NewBundle returns an empty bundle of the schema version.
+func NewBundle() Bundle {
+	return Bundle{SchemaVersion: SchemaVersion, Files: map[string]interface{}{}}
+}
+
+// Add the document to the bundle in the task mode shape.
+func (b *Bundle) Add(document Document, task configuration.Task) {
+	if b.Files == nil {
+		*b = NewBundle()
+	}
+	b.Files[document.Key()] = document.shape(task)
+}
+
+// Write the bundle file format to persistant storage within the directory and return its path; the task format selects the encoder and a compressed bundle is gzip encoded.
+func (b Bundle) Write(directory string, task configuration.Task, compress bool) (file string, err error) {
+	encoder, err := NewEncoder(task.Format)
+	if err != nil {
+		return file, err
+	}
+	if b.Files == nil {
+		b = NewBundle()
+	}
+	file = filepath.Join(directory, BundleName+encoder.Extension())
+	data, err := encoder.Encode(b)
+	if err != nil {
+		return file, err
+	}
+	if compress {
+		var buffer bytes.Buffer
+		writer := gzip.NewWriter(&buffer)
+		_, err = writer.Write(data)
+		if err == nil {
+			err = writer.Close()
+		}
+		if err != nil {
+			return file, err
+		}
+		file += CompressionExtension
+		data = buffer.Bytes()
+	}
+	err = os.MkdirAll(directory, os.ModePerm)
+	if err == nil {
+		err = ioutil.WriteFile(file, data, 0644)
+	}
+	return file, err
+}
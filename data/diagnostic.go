@@ -0,0 +1,18 @@
+package data
+
+import "fmt"
+
+// Diagnostic structure reports a problem found while processing a source file; it does not prevent the file from being emitted.
+type Diagnostic struct {
+	Path    string `json:"path,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// String returns the diagnostic in the path:line message format.
+func (d Diagnostic) String() string {
+	if d.Line > 0 {
+		return fmt.Sprintf("%s:%v %s", d.Path, d.Line, d.Message)
+	}
+	return fmt.Sprintf("%s %s", d.Path, d.Message)
+}
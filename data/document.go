@@ -0,0 +1,40 @@
+package data
+
+import "fmt"
+
+// split returns one document per top-level node with the keyword; top-level nodes preceding the first split node are kept in an unnamed document. Documents are named by the identifier of their split node (suffixed when an explicit identifier is duplicated) and diagnostics follow the lines they were reported on.
+func (d Document) split(keyword string) (documents []Document) {
+	var current *Document
+	var lines []int
+	used := map[string]bool{}
+	for _, n := range d.Data {
+		if n.Keyword == keyword || current == nil {
+			document := d
+			document.Data = nil
+			document.Diagnostics = nil
+			if n.Keyword == keyword {
+				document.File.Document = n.ID
+				for suffix := 2; used[document.File.Document]; suffix++ {
+					document.File.Document = fmt.Sprintf("%s-%v", n.ID, suffix)
+				}
+				used[document.File.Document] = true
+			}
+			documents = append(documents, document)
+			lines = append(lines, n.Line)
+			current = &documents[len(documents)-1]
+		}
+		current.Data = append(current.Data, n)
+	}
+	for _, diagnostic := range d.Diagnostics {
+		for i := len(documents) - 1; i >= 0; i-- {
+			if diagnostic.Line >= lines[i] || i == 0 {
+				documents[i].Diagnostics = append(documents[i].Diagnostics, diagnostic)
+				break
+			}
+		}
+	}
+	if len(documents) == 0 {
+		documents = append(documents, d)
+	}
+	return documents
+}
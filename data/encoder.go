@@ -0,0 +1,127 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/emits-io/emits/configuration"
+	"github.com/emits-io/emits/internal/markup"
+)
+
+const (
+	// FormatJSON output format; it is the default format.
+	FormatJSON = "json"
+	// FormatYAML output format
+	FormatYAML = "yaml"
+	// FormatTOML output format
+	FormatTOML = "toml"
+)
+
+// Encoder interface writes the emitted structures in an output format.
+type Encoder interface {
+	// Extension returns the file extension including the leading separator.
+	Extension() string
+	// ContentType returns the media type served for the format.
+	ContentType() string
+	// Encode returns the encoded value.
+	Encode(value interface{}) ([]byte, error)
+}
+
+var encoders = map[string]Encoder{
+	FormatJSON: jsonEncoder{},
+	FormatYAML: yamlEncoder{},
+	FormatTOML: tomlEncoder{},
+}
+
+func init() {
+	for format := range encoders {
+		configuration.RegisterFormat(format)
+	}
+}
+
+// RegisterEncoder adds or replaces the encoder of an output format; the format is registered as a valid task format.
+func RegisterEncoder(format string, encoder Encoder) {
+	encoders[strings.ToLower(format)] = encoder
+	configuration.RegisterFormat(format)
+}
+
+// NewEncoder returns the encoder of an output format; an empty format returns the json encoder.
+func NewEncoder(format string) (Encoder, error) {
+	if len(format) == 0 {
+		format = FormatJSON
+	}
+	if encoder, ok := encoders[strings.ToLower(format)]; ok {
+		return encoder, nil
+	}
+	return nil, fmt.Errorf("%s is not a valid format", format)
+}
+
+// Formats returns the names of the registered output formats.
+func Formats() (formats []string) {
+	for f := range encoders {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// ContentType returns the media type of a file name with a registered output format extension.
+func ContentType(name string) (contentType string, ok bool) {
+	for _, f := range Formats() {
+		if strings.HasSuffix(name, encoders[f].Extension()) {
+			return encoders[f].ContentType(), true
+		}
+	}
+	return "", false
+}
+
+// jsonEncoder writes indented json.
+type jsonEncoder struct{}
+
+func (jsonEncoder) Extension() string {
+	return ".json"
+}
+
+func (jsonEncoder) ContentType() string {
+	return "application/json"
+}
+
+func (jsonEncoder) Encode(value interface{}) ([]byte, error) {
+	return json.MarshalIndent(value, "", "\t")
+}
+
+// yamlEncoder writes block style yaml; the value is encoded through json so the json field names and key order are kept.
+type yamlEncoder struct{}
+
+func (yamlEncoder) Extension() string {
+	return ".yaml"
+}
+
+func (yamlEncoder) ContentType() string {
+	return "application/yaml"
+}
+
+func (yamlEncoder) Encode(value interface{}) ([]byte, error) {
+	node, err := markup.YAMLNode(value)
+	if err != nil {
+		return nil, err
+	}
+	return markup.EncodeYAML(node)
+}
+
+// tomlEncoder writes toml; the value is encoded through json so the json field names are kept.
+type tomlEncoder struct{}
+
+func (tomlEncoder) Extension() string {
+	return ".toml"
+}
+
+func (tomlEncoder) ContentType() string {
+	return "application/toml"
+}
+
+func (tomlEncoder) Encode(value interface{}) ([]byte, error) {
+	return markup.EncodeTOML(value)
+}
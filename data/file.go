@@ -2,13 +2,11 @@ package data
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 	"unicode"
 
 	"github.com/emits-io/emits/configuration"
@@ -21,8 +19,6 @@ const (
 	collapsing = ":"
 	// config constant referenced by process function
 	config = emits + separator
-	// fileExtension constant referenced by the Write function
-	fileExtension = ".json"
 	// emits constant referenced by the configuration constant
 	emits = "emits"
 	// separator constant referenced by process function and configuration constant
@@ -33,24 +29,30 @@ const (
 	flag = "`"
 	// flagSeparator
 	flagSeparator = ","
+	// flagAssign character separates a flag attribute name from its value
+	flagAssign = "="
 	// indent character referenced by the process function
 	indent = ">"
 	// outdent character referenced by the process function
 	outdent = "<"
 )
 
-// emit structure is used to write the json file format.
-type emit struct {
-	File          file   `json:"file,omitempty"`
-	Configuration []Node `json:"configuration,omitempty"`
-	Data          []Node `json:"data,omitempty"`
+// Document structure is used to write the json file format; a source file is emitted as one document unless the task splits it.
+type Document struct {
+	name          string
+	SchemaVersion string       `json:"schemaVersion"`
+	File          File         `json:"file,omitempty"`
+	Configuration []Node       `json:"configuration,omitempty"`
+	Data          []Node       `json:"data,omitempty"`
+	Diagnostics   []Diagnostic `json:"diagnostic,omitempty"`
 }
 
-// file structure components available within the emits structure.
-type file struct {
+// File structure components available within the document structure.
+type File struct {
 	Path      string `json:"path,omitempty"`
 	Name      string `json:"name,omitempty"`
 	Extension string `json:"extension,omitempty"`
+	Document  string `json:"document,omitempty"`
 	Timestamp string `json:"timestamp,omitempty"`
 }
 
@@ -62,16 +64,34 @@ type FileComment struct {
 	Inline     string
 }
 
-// write the emit file format to persistant storage.
-func (e emit) write(file string) (err error) {
-	data, err := json.MarshalIndent(e, "", "\t")
+// Source returns the source file path of the document.
+func (d Document) Source() string {
+	return d.name
+}
+
+// Key returns the source file name of the document; split documents are suffixed with the document name.
+func (d Document) Key() string {
+	if len(d.File.Document) > 0 {
+		return d.name + separator + d.File.Document
+	}
+	return d.name
+}
+
+// Write the document file format to persistant storage within an optional prefix directory and return its path; the task mode selects the node or object shape, the task format selects the encoder and the task output path template names the file.
+func (d Document) Write(task configuration.Task, prefixDirectory ...string) (file string, err error) {
+	encoder, err := NewEncoder(task.Format)
+	if err != nil {
+		return file, err
+	}
+	file = filepath.Join(filepath.Join(prefixDirectory...), task.OutputFile(d.name, d.File.Document, encoder.Extension()))
+	data, err := encoder.Encode(d.shape(task))
 	if err == nil {
 		err = os.MkdirAll(filepath.Dir(file), os.ModePerm)
 		if err == nil {
 			err = ioutil.WriteFile(file, data, 0644)
 		}
 	}
-	return err
+	return file, err
 }
 
 func cleanSpace(line string) (index int, clean string) {
@@ -168,8 +188,14 @@ func keywordValueFlagIndex(line string, index int) (keyword string, value string
 		//
 		flagsOverride := ""
 		if strings.HasPrefix(keywordMeta, flag) && strings.HasSuffix(keywordMeta, flag) {
+			attribute := false
 			for _, c := range keywordMeta[1 : len(keywordMeta)-1] {
-				if unicode.IsLetter(c) || unicode.IsDigit(c) || string(c) == flagSeparator {
+				if string(c) == flagSeparator {
+					attribute = false
+				} else if string(c) == flagAssign {
+					attribute = true
+				}
+				if unicode.IsLetter(c) || unicode.IsDigit(c) || string(c) == flagSeparator || string(c) == flagAssign || attribute && strings.ContainsRune(attributeCharacters, c) {
 					flagsOverride += string(c)
 				}
 			}
@@ -188,7 +214,7 @@ func keywordValueFlagIndex(line string, index int) (keyword string, value string
 func process(line string, lineNumber int, task configuration.Task, previous Node) Node {
 	// Options
 	isAppending, isCollapsing, isNewline, isConfiguration, isSeparator, isCommentInline, isCommentBlockOpen, isCommentBlockClose, isCommentBlockLine := false, false, false, false, false, false, false, false, false
-	keyword, value := "", ""
+	keyword, value, identifier, reference := "", "", "", ""
 	var flags []string
 	index := 0
 	// Clean Up
@@ -217,6 +243,7 @@ func process(line string, lineNumber int, task configuration.Task, previous Node
 	}
 	if isCommentBlockOpen || isCommentBlockLine || isCommentBlockClose || isCommentInline {
 		keyword, value, flags, index = keywordValueFlagIndex(line, index)
+		flags, identifier, reference = flagAttributes(flags)
 		if index == 0 && strings.HasPrefix(keyword, config) {
 			// Configuration
 			keyword = keyword[len(config):]
@@ -254,6 +281,8 @@ func process(line string, lineNumber int, task configuration.Task, previous Node
 		Keyword:       keyword,
 		Value:         value,
 		Flags:         flags,
+		ID:            identifier,
+		Reference:     reference,
 		Separator:     isSeparator,
 		Configuration: isConfiguration,
 		Appending:     isAppending,
@@ -320,7 +349,7 @@ func Parse(name string, task configuration.Task) (tree Node, config []Node, err
 		} else {
 			// Explicit flag required to expose source code; default's to false.
 			value := ""
-			if task.Source {
+			if configuration.Enabled(task.Source) {
 				value = strings.TrimSpace(text)
 			}
 			appendNode := tree.LastNode().LastAppendingNodeOrRoot()
@@ -339,8 +368,8 @@ func Parse(name string, task configuration.Task) (tree Node, config []Node, err
 	return tree, config, scanner.Err()
 }
 
-// Write the emits json file to an optional prefix directory.
-func Write(name string, task configuration.Task, prefixDirectory ...string) (err error) {
+// Read returns the documents of a source file; a task split keyword returns one document per split node. Diagnostics are returned for problems that do not prevent the documents from being emitted.
+func Read(name string, task configuration.Task) (documents []Document, diagnostics []Diagnostic, err error) {
 	nodes, configurations, err := Parse(name, task)
 
 	if len(task.Keyword.Include) > 0 && !nodes.HasInstanceOfKeyword(task.Keyword.Include) {
@@ -378,21 +407,57 @@ func Write(name string, task configuration.Task, prefixDirectory ...string) (err
 		}
 	}
 
+	stamp := ""
+	if err == nil {
+		stamp, err = timestamp(name, task)
+	}
+
 	if err == nil {
 
 		nodes.CollapseAppending()
 
-		file := emit{
-			File: file{
+		document := Document{
+			name:          name,
+			SchemaVersion: SchemaVersion,
+			File: File{
 				Path:      filepath.Dir(name),
 				Name:      strings.TrimSuffix(filepath.Base(name), filepath.Ext(filepath.Base(name))),
 				Extension: strings.TrimPrefix(filepath.Ext(name), "."),
-				Timestamp: time.Now().UTC().String(),
+				Timestamp: stamp,
 			},
 			Configuration: configurations,
-			Data:          nodes.Children,
 		}
-		return file.write(filepath.Join(filepath.Join(prefixDirectory...), name+fileExtension))
+
+		diagnostics = append(diagnostics, nodes.identify()...)
+
+		diagnostics = append(diagnostics, nodes.interpolate(variables{source: name, file: document.File, configuration: configurations, task: task})...)
+		for i := range diagnostics {
+			diagnostics[i].Path = name
+		}
+
+		document.Data = nodes.Children
+		document.Diagnostics = diagnostics
+
+		if len(task.Split) > 0 {
+			return document.split(task.Split), diagnostics, nil
+		}
+		return []Document{document}, diagnostics, nil
+	}
+	return documents, diagnostics, err
+}
+
+// Write the emits files to an optional prefix directory and return their paths; a task split keyword writes one file per document. Diagnostics are returned for problems that do not prevent the files from being written.
+func Write(name string, task configuration.Task, prefixDirectory ...string) (files []string, diagnostics []Diagnostic, err error) {
+	documents, diagnostics, err := Read(name, task)
+	if err != nil {
+		return files, diagnostics, err
+	}
+	for _, d := range documents {
+		file, err := d.Write(task, prefixDirectory...)
+		if err != nil {
+			return files, diagnostics, err
+		}
+		files = append(files, file)
 	}
-	return err
+	return files, diagnostics, nil
 }
@@ -0,0 +1,96 @@
+package data
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// identifierAttribute flag attribute overrides the derived node identifier
+	identifierAttribute = "id"
+	// referenceAttribute flag attribute references another node identifier
+	referenceAttribute = "ref"
+	// identifierLength number of hexadecimal characters used by a derived identifier
+	identifierLength = 12
+	// attributeCharacters allowed within a flag attribute value in addition to letters and digits
+	attributeCharacters = "-_./#"
+)
+
+// flagAttributes removes the id and ref attributes from the flags and returns their values.
+func flagAttributes(flags []string) (clean []string, identifier string, reference string) {
+	for _, f := range flags {
+		split := strings.SplitN(f, flagAssign, 2)
+		if len(split) == 2 && split[0] == identifierAttribute {
+			identifier = split[1]
+		} else if len(split) == 2 && split[0] == referenceAttribute {
+			reference = split[1]
+		} else {
+			clean = append(clean, f)
+		}
+	}
+	return clean, identifier, reference
+}
+
+// identify assigns a stable identifier to every node of the tree; identifiers are derived from the keyword path and value so they do not change when lines move.
+func (n *Node) identify() (diagnostics []Diagnostic) {
+	used := map[string]int{}
+	diagnostics = n.reserveIdentifiers(used)
+	n.deriveIdentifiers("", used)
+	return append(diagnostics, n.resolveReferences(used)...)
+}
+
+// reserveIdentifiers (recursive) reserves the explicit identifiers of the tree so derived identifiers cannot collide with them.
+func (n *Node) reserveIdentifiers(used map[string]int) (diagnostics []Diagnostic) {
+	for _, c := range n.Children {
+		if len(c.ID) > 0 {
+			if line, ok := used[c.ID]; ok {
+				diagnostics = append(diagnostics, Diagnostic{
+					Line:    c.Line,
+					Message: fmt.Sprintf("duplicate id `%s`; first declared on line %v", c.ID, line),
+				})
+			} else {
+				used[c.ID] = c.Line
+			}
+		}
+		diagnostics = append(diagnostics, c.reserveIdentifiers(used)...)
+	}
+	return diagnostics
+}
+
+// resolveReferences (recursive) returns a diagnostic for every reference that does not match an identifier of the tree.
+func (n *Node) resolveReferences(used map[string]int) (diagnostics []Diagnostic) {
+	for _, c := range n.Children {
+		if _, ok := used[c.Reference]; len(c.Reference) > 0 && !ok {
+			diagnostics = append(diagnostics, Diagnostic{
+				Line:    c.Line,
+				Message: fmt.Sprintf("ref `%s` does not match an id", c.Reference),
+			})
+		}
+		diagnostics = append(diagnostics, c.resolveReferences(used)...)
+	}
+	return diagnostics
+}
+
+// deriveIdentifiers (recursive) derives the identifiers of the nodes without an explicit identifier; duplicates receive a numeric suffix in document order.
+func (n *Node) deriveIdentifiers(path string, used map[string]int) {
+	for i, c := range n.Children {
+		keywordPath := c.Keyword
+		if len(path) > 0 {
+			keywordPath = path + separator + c.Keyword
+		}
+		if len(c.ID) == 0 {
+			hash := sha1.Sum([]byte(keywordPath + "\x00" + c.Value))
+			id := hex.EncodeToString(hash[:])[:identifierLength]
+			unique := id
+			for suffix := 2; used[unique] > 0; suffix++ {
+				unique = fmt.Sprintf("%s-%v", id, suffix)
+			}
+			used[unique] = c.Line
+			n.Children[i].ID = unique
+			n.Children[i].Derived = true
+		}
+		n.Children[i].deriveIdentifiers(keywordPath, used)
+	}
+}
@@ -10,6 +10,8 @@ type Node struct {
 	Configuration bool     `json:"-"`
 	ParentNode    *Node    `json:"-"`
 	Comment       Comment  `json:"-"`
+	Derived       bool     `json:"-"`
+	ID            string   `json:"id,omitempty"`
 	Parent        int      `json:"parent,omitempty"`
 	Line          int      `json:"line,omitempty"`
 	Index         int      `json:"index,omitempty"`
@@ -18,6 +20,7 @@ type Node struct {
 	Children      []Node   `json:"data,omitempty"`
 	Separator     bool     `json:"separator,omitempty"`
 	Flags         []string `json:"flags,omitempty"`
+	Reference     string   `json:"ref,omitempty"`
 }
 
 // Comment structure
@@ -118,6 +121,30 @@ func (n *Node) HasInstanceOfKeyword(keyword []string) bool {
 	return false
 }
 
+// NodeWithID (recursive) returns the first node in the tree structure with a matching identifier; or nil.
+func (n *Node) NodeWithID(id string) (node *Node) {
+	for i := range n.Children {
+		if n.Children[i].ID == id {
+			return &n.Children[i]
+		}
+		if node := n.Children[i].NodeWithID(id); node != nil {
+			return node
+		}
+	}
+	return nil
+}
+
+// Find (recursive) returns every node in the tree structure with the keyword in document order.
+func (n *Node) Find(keyword string) (nodes []Node) {
+	for i := range n.Children {
+		if n.Children[i].Keyword == keyword {
+			nodes = append(nodes, n.Children[i])
+		}
+		nodes = append(nodes, n.Children[i].Find(keyword)...)
+	}
+	return nodes
+}
+
 // CollapseAppending func
 func (n *Node) CollapseAppending() {
 	for i, c := range n.Children {
@@ -0,0 +1,191 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/emits-io/emits/configuration"
+)
+
+const (
+	// objectValue key holds the value of a node that also has children in the object mode.
+	objectValue = "_value"
+	// objectFlags key holds the flags of a node in the object mode.
+	objectFlags = "_flags"
+	// objectID key holds the identifier of a node in the object mode.
+	objectID = "_id"
+	// objectReference key holds the identifier a node refers to in the object mode.
+	objectReference = "_ref"
+)
+
+// objectDocument structure is used to write the object mode file format.
+type objectDocument struct {
+	SchemaVersion string       `json:"schemaVersion"`
+	File          File         `json:"file,omitempty"`
+	Configuration *object      `json:"configuration,omitempty"`
+	Data          *object      `json:"data,omitempty"`
+	Diagnostics   []Diagnostic `json:"diagnostic,omitempty"`
+}
+
+// object structure is a json object that keeps the order its keys were set in.
+type object struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newObject() *object {
+	return &object{values: map[string]interface{}{}}
+}
+
+// set the key value; an existing key keeps its position.
+func (o *object) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// add the key value; repeated keys and array keywords are collected into an array.
+func (o *object) add(key string, value interface{}, array bool) {
+	existing, ok := o.values[key]
+	if !ok {
+		if array {
+			value = []interface{}{value}
+		}
+		o.set(key, value)
+		return
+	}
+	if values, ok := existing.([]interface{}); ok {
+		o.values[key] = append(values, value)
+		return
+	}
+	o.values[key] = []interface{}{existing, value}
+}
+
+// MarshalJSON writes the object keys in order.
+func (o *object) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("{")
+	for i, k := range o.keys {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(o.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(key)
+		buffer.WriteString(":")
+		buffer.Write(value)
+	}
+	buffer.WriteString("}")
+	return buffer.Bytes(), nil
+}
+
+// shape returns the structure written for the task mode.
+func (d Document) shape(task configuration.Task) interface{} {
+	if task.Mode != configuration.ModeObject {
+		return d
+	}
+	shaped := objectDocument{
+		SchemaVersion: d.SchemaVersion,
+		File:          d.File,
+		Diagnostics:   d.Diagnostics,
+	}
+	if len(d.Configuration) > 0 {
+		shaped.Configuration = newObject()
+		for _, n := range d.Configuration {
+			shaped.Configuration.add(n.Keyword, n.Value, isArray(n.Keyword, task))
+		}
+	}
+	if len(d.Data) > 0 {
+		shaped.Data = objectNodes(d.Data, task)
+	}
+	return shaped
+}
+
+// objectNodes (recursive) maps keywords to object keys; values of nodes without a keyword are joined into the _value key.
+func objectNodes(nodes []Node, task configuration.Task) *object {
+	shaped := newObject()
+	var values []string
+	for _, n := range nodes {
+		if len(n.Keyword) == 0 {
+			if len(n.Value) > 0 {
+				values = append(values, n.Value)
+			}
+			continue
+		}
+		shaped.add(n.Keyword, objectNode(n, task), isArray(n.Keyword, task))
+	}
+	if len(values) > 0 {
+		shaped.set(objectValue, strings.Join(values, "\n"))
+	}
+	return shaped
+}
+
+// objectNode returns the node value; a node with children, flags, a declared identifier or a reference is returned as an object with its identifier.
+func objectNode(n Node, task configuration.Task) interface{} {
+	if len(n.Children) == 0 && len(n.Flags) == 0 && (len(n.ID) == 0 || n.Derived) && len(n.Reference) == 0 {
+		return n.Value
+	}
+	children := objectNodes(n.Children, task)
+	shaped := newObject()
+	if value, ok := children.values[objectValue]; ok && len(n.Value) > 0 {
+		shaped.set(objectValue, n.Value+"\n"+value.(string))
+	} else if len(n.Value) > 0 {
+		shaped.set(objectValue, n.Value)
+	}
+	if len(n.Flags) > 0 {
+		shaped.set(objectFlags, n.Flags)
+	}
+	if len(n.ID) > 0 {
+		shaped.set(objectID, n.ID)
+	}
+	if len(n.Reference) > 0 {
+		shaped.set(objectReference, n.Reference)
+	}
+	for _, k := range children.keys {
+		if _, ok := shaped.values[k]; ok && k == objectValue {
+			continue
+		}
+		shaped.set(k, children.values[k])
+	}
+	return shaped
+}
+
+func isArray(keyword string, task configuration.Task) bool {
+	for _, a := range task.Array {
+		if a == keyword {
+			return true
+		}
+	}
+	return false
+}
+
+// objectWithID (recursive) returns the object mode object with the identifier in the json; the keys keep their order.
+func objectWithID(read json.RawMessage, id string) json.RawMessage {
+	var values []json.RawMessage
+	var keys map[string]json.RawMessage
+	if json.Unmarshal(read, &keys) == nil {
+		var identifier string
+		if json.Unmarshal(keys[objectID], &identifier) == nil && identifier == id {
+			return read
+		}
+		for _, v := range keys {
+			values = append(values, v)
+		}
+	} else if json.Unmarshal(read, &values) != nil {
+		return nil
+	}
+	for _, v := range values {
+		if found := objectWithID(v, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/emits-io/emits/configuration"
+)
+
+// SchemaVersion of the emitted documents, bundles, stream records and task index.
+//
+// The major version changes when a field is removed, renamed or changes type; the minor version changes when a field is added.
+// Consumers should accept any document with the major version they were written for.
+const SchemaVersion = "1.1"
+
+const (
+	// SchemaDocument names the schema of an emitted document.
+	SchemaDocument = "document"
+	// SchemaIndex names the schema of a task index.
+	SchemaIndex = "index"
+	// SchemaBundle names the schema of a task bundle.
+	SchemaBundle = "bundle"
+	// SchemaRecord names the schema of a stream record.
+	SchemaRecord = "record"
+	// schemaDialect of the generated json schemas
+	schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+)
+
+var schemas = map[string]interface{}{
+	SchemaDocument: new(interface{}),
+	SchemaIndex:    configuration.Index{},
+	SchemaBundle:   Bundle{},
+	SchemaRecord:   Record{},
+}
+
+// Schemas returns the names of the available schemas.
+func Schemas() (names []string) {
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Compatible returns true if a schema version can be read by this version; only the major versions must match.
+func Compatible(version string) bool {
+	return strings.SplitN(version, separator, 2)[0] == strings.SplitN(SchemaVersion, separator, 2)[0]
+}
+
+// Schema returns the json schema generated from the structure of the named schema; documents are described in the node and object mode shapes.
+func Schema(name string) ([]byte, error) {
+	value, ok := schemas[name]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a valid schema", name)
+	}
+	definitions := map[string]interface{}{}
+	root := schemaType(reflect.TypeOf(value), definitions).(map[string]interface{})
+	root["$schema"] = schemaDialect
+	root["$id"] = fmt.Sprintf("urn:emits:schema:%s:%s", SchemaVersion, name)
+	root["title"] = fmt.Sprintf("emits %s", name)
+	root["$defs"] = definitions
+	return json.MarshalIndent(root, "", "\t")
+}
+
+// schemaObject returns the schema of an object mode object; keys hold a value, a nested object or an array of either.
+func schemaObject(definitions map[string]interface{}) interface{} {
+	reference := map[string]interface{}{"$ref": "#/$defs/object"}
+	if _, ok := definitions["object"]; ok {
+		return reference
+	}
+	value := []interface{}{map[string]interface{}{"type": "string"}, reference}
+	definitions["object"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			objectValue:     map[string]interface{}{"type": "string"},
+			objectFlags:     map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			objectID:        map[string]interface{}{"type": "string"},
+			objectReference: map[string]interface{}{"type": "string"},
+		},
+		"additionalProperties": map[string]interface{}{
+			"anyOf": append(value, map[string]interface{}{"type": "array", "items": map[string]interface{}{"anyOf": value}}),
+		},
+	}
+	return reference
+}
+
+// schemaType (recursive) returns the schema of a type; structures are added to the definitions and referenced and interface values are documents in either mode shape.
+func schemaType(t reflect.Type, definitions map[string]interface{}) interface{} {
+	if t == reflect.TypeOf(object{}) {
+		return schemaObject(definitions)
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		return map[string]interface{}{"anyOf": []interface{}{
+			schemaType(reflect.TypeOf(Document{}), definitions),
+			schemaType(reflect.TypeOf(objectDocument{}), definitions),
+		}}
+	case reflect.Ptr:
+		return schemaType(t.Elem(), definitions)
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaType(t.Elem(), definitions)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaType(t.Elem(), definitions)}
+	case reflect.Struct:
+		reference := map[string]interface{}{"$ref": "#/$defs/" + t.Name()}
+		if _, ok := definitions[t.Name()]; ok {
+			return reference
+		}
+		definition := map[string]interface{}{"type": "object"}
+		definitions[t.Name()] = definition
+		properties := map[string]interface{}{}
+		var required []string
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := strings.Split(field.Tag.Get("json"), ",")
+			if len(field.PkgPath) > 0 || tag[0] == "-" {
+				continue
+			}
+			name := tag[0]
+			if len(name) == 0 {
+				name = field.Name
+			}
+			properties[name] = schemaType(field.Type, definitions)
+			if !strings.Contains(strings.Join(tag[1:], ","), "omitempty") {
+				required = append(required, name)
+			}
+		}
+		definition["properties"] = properties
+		if len(required) > 0 {
+			definition["required"] = required
+		}
+		return reference
+	}
+	return map[string]interface{}{}
+}
+
+// Tree returns the node tree of an emitted json document; documents with an incompatible schema version are not read.
+func Tree(read []byte) (tree Node, err error) {
+	var document struct {
+		SchemaVersion string `json:"schemaVersion"`
+		Data          []Node `json:"data"`
+	}
+	err = json.Unmarshal(read, &document)
+	if err != nil {
+		return tree, err
+	}
+	if !Compatible(document.SchemaVersion) {
+		return tree, fmt.Errorf("schema version %s is not compatible with %s", document.SchemaVersion, SchemaVersion)
+	}
+	tree.Children = document.Data
+	return tree, nil
+}
+
+// Identified returns the json of the node with the identifier in an emitted json document of either mode shape; it is nil if no node has the identifier.
+func Identified(read []byte, id string) (json.RawMessage, error) {
+	var document struct {
+		SchemaVersion string          `json:"schemaVersion"`
+		Data          json.RawMessage `json:"data"`
+	}
+	err := json.Unmarshal(read, &document)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(strings.TrimSpace(string(document.Data)), "{") {
+		tree, err := Tree(read)
+		if err != nil {
+			return nil, err
+		}
+		node := tree.NodeWithID(id)
+		if node == nil {
+			return nil, nil
+		}
+		return json.Marshal(node)
+	}
+	if !Compatible(document.SchemaVersion) {
+		return nil, fmt.Errorf("schema version %s is not compatible with %s", document.SchemaVersion, SchemaVersion)
+	}
+	return objectWithID(document.Data, id), nil
+}
+
+// Emitted returns the document of an emitted node mode json document; documents of another shape, format or an incompatible schema version are not read.
+func Emitted(read []byte) (document Document, err error) {
+	err = json.Unmarshal(read, &document)
+	if err != nil {
+		return document, err
+	}
+	if !Compatible(document.SchemaVersion) {
+		return document, fmt.Errorf("schema version %s is not compatible with %s", document.SchemaVersion, SchemaVersion)
+	}
+	document.name = document.File.Name
+	if len(document.File.Extension) > 0 {
+		document.name += separator + document.File.Extension
+	}
+	document.name = filepath.Join(filepath.FromSlash(document.File.Path), document.name)
+	return document, nil
+}
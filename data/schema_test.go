@@ -0,0 +1,292 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	flags "flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/emits-io/emits/configuration"
+)
+
+// read returns the documents of a temporary go source file emitted by the task.
+func read(t *testing.T, task configuration.Task) []Document {
+	directory, err := ioutil.TempDir("", "emits")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+	file := filepath.Join(directory, "a.go")
+	err = ioutil.WriteFile(file, []byte("package a\n\n// .name a\n// > .value b\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	documents, _, err := Read(file, task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(documents) == 0 {
+		t.Fatal("no documents were read")
+	}
+	return documents
+}
+
+// schemaVersion returns the schemaVersion field of the json.
+func schemaVersion(t *testing.T, data []byte) string {
+	var versioned struct {
+		SchemaVersion string `json:"schemaVersion"`
+	}
+	err := json.Unmarshal(data, &versioned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return versioned.SchemaVersion
+}
+
+func TestCompatible(t *testing.T) {
+	major := strings.SplitN(SchemaVersion, ".", 2)[0]
+	for version, compatible := range map[string]bool{
+		SchemaVersion:        true,
+		major:                true,
+		major + ".0":         true,
+		major + ".99":        true,
+		"99.0":               false,
+		"0" + major:          false,
+		"":                   false,
+		major + "1.0":        false,
+		"x." + SchemaVersion: false,
+	} {
+		if Compatible(version) != compatible {
+			t.Errorf("Compatible(%q) = %v, want %v", version, !compatible, compatible)
+		}
+	}
+}
+
+func TestSchemaVersion(t *testing.T) {
+	for _, mode := range []string{configuration.ModeNode, configuration.ModeObject} {
+		task := configuration.Task{Name: "go", Mode: mode, Comment: configuration.Comment{Inline: "//"}}
+		documents := read(t, task)
+		outputs := map[string][]byte{}
+		data, err := json.Marshal(documents[0].shape(task))
+		if err != nil {
+			t.Fatal(err)
+		}
+		outputs[SchemaDocument] = data
+		data, err = json.Marshal(configuration.Index{SchemaVersion: SchemaVersion})
+		if err != nil {
+			t.Fatal(err)
+		}
+		outputs[SchemaIndex] = data
+		bundle := Bundle{}
+		bundle.Add(documents[0], task)
+		data, err = json.Marshal(bundle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		outputs[SchemaBundle] = data
+		var buffer bytes.Buffer
+		err = NewStream(&buffer, task).Document(documents[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		outputs[SchemaRecord] = buffer.Bytes()
+		for name, output := range outputs {
+			if version := schemaVersion(t, output); version != SchemaVersion {
+				t.Errorf("%s %s schemaVersion = %q, want %q", mode, name, version, SchemaVersion)
+			}
+		}
+		var record Record
+		err = json.Unmarshal(outputs[SchemaRecord], &record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version := schemaVersion(t, mustMarshal(t, record.Document)); version != SchemaVersion {
+			t.Errorf("%s record document schemaVersion = %q, want %q", mode, version, SchemaVersion)
+		}
+	}
+}
+
+func TestSchema(t *testing.T) {
+	if len(Schemas()) != len(schemas) {
+		t.Fatalf("Schemas() = %v", Schemas())
+	}
+	for _, name := range Schemas() {
+		data, err := Schema(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var schema map[string]interface{}
+		err = json.Unmarshal(data, &schema)
+		if err != nil {
+			t.Fatalf("%s schema is not valid json: %v", name, err)
+		}
+		if id, _ := schema["$id"].(string); !strings.Contains(id, SchemaVersion) || !strings.HasSuffix(id, name) {
+			t.Errorf("%s schema $id = %q", name, id)
+		}
+		definitions, _ := schema["$defs"].(map[string]interface{})
+		for _, reference := range references(schema) {
+			if _, ok := definitions[strings.TrimPrefix(reference, "#/$defs/")]; !ok {
+				t.Errorf("%s schema reference %s is not defined", name, reference)
+			}
+		}
+		for _, definition := range definitions {
+			properties, _ := definition.(map[string]interface{})["properties"].(map[string]interface{})
+			for property, value := range properties {
+				if len(value.(map[string]interface{})) == 0 {
+					t.Errorf("%s schema property %s is not described", name, property)
+				}
+			}
+		}
+	}
+	data, err := Schema(SchemaDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, shape := range []string{`"#/$defs/Document"`, `"#/$defs/objectDocument"`, `"#/$defs/object"`} {
+		if !bytes.Contains(data, []byte(shape)) {
+			t.Errorf("document schema does not reference %s", shape)
+		}
+	}
+	_, err = Schema("unknown")
+	if err == nil {
+		t.Error("Schema(unknown) did not return an error")
+	}
+}
+
+// references (recursive) returns the $ref values within the schema.
+func references(schema interface{}) (found []string) {
+	switch value := schema.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			if reference, ok := v.(string); ok && k == "$ref" {
+				found = append(found, reference)
+			}
+			found = append(found, references(v)...)
+		}
+	case []interface{}:
+		for _, v := range value {
+			found = append(found, references(v)...)
+		}
+	}
+	return found
+}
+
+func mustMarshal(t *testing.T, value interface{}) []byte {
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+var update = flags.Bool("update", false, "write the golden schema files of testdata")
+
+// goldenSchemas are compared to the generated schemas; go test -update writes them after a version change.
+var goldenSchemas = []string{SchemaDocument, SchemaIndex, SchemaBundle}
+
+func TestSchemaGolden(t *testing.T) {
+	for _, name := range goldenSchemas {
+		generated, err := Schema(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file := filepath.Join("testdata", name+".schema.json")
+		if *update {
+			err = ioutil.WriteFile(file, append(generated, '\n'), 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		read, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatalf("%v; run go test -update to write the golden schema", err)
+		}
+		golden, current := schemaFields(t, read), schemaFields(t, generated)
+		version := goldenVersion(t, read)
+		major := strings.SplitN(version, ".", 2)[0] == strings.SplitN(SchemaVersion, ".", 2)[0]
+		for field, kind := range golden {
+			if _, ok := current[field]; !ok && major {
+				t.Errorf("%s schema field %s was removed or renamed; the major version of %s must change", name, field, version)
+			} else if ok && current[field] != kind && major {
+				t.Errorf("%s schema field %s changed type from %s to %s; the major version of %s must change", name, field, kind, current[field], version)
+			}
+		}
+		for field := range current {
+			if _, ok := golden[field]; !ok && version == SchemaVersion {
+				t.Errorf("%s schema field %s was added; the minor version of %s must change", name, field, version)
+			}
+		}
+		if version != SchemaVersion && !t.Failed() {
+			t.Errorf("%s schema version changed from %s; run go test -update to write the golden schema", name, version)
+		}
+	}
+}
+
+// goldenVersion returns the schema version of the $id of a schema.
+func goldenVersion(t *testing.T, read []byte) string {
+	var schema struct {
+		ID string `json:"$id"`
+	}
+	err := json.Unmarshal(read, &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	split := strings.Split(schema.ID, ":")
+	if len(split) < 2 {
+		t.Fatalf("%s is not a schema id", schema.ID)
+	}
+	return split[len(split)-2]
+}
+
+// schemaFields returns the json path of every field of a schema with its type; [] is an array item and {} an object value.
+func schemaFields(t *testing.T, read []byte) map[string]string {
+	var schema map[string]interface{}
+	err := json.Unmarshal(read, &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	definitions, _ := schema["$defs"].(map[string]interface{})
+	fields := map[string]string{}
+	var walk func(value interface{}, path string, visiting map[string]bool)
+	walk = func(value interface{}, path string, visiting map[string]bool) {
+		node, _ := value.(map[string]interface{})
+		if reference, ok := node["$ref"].(string); ok {
+			if visiting[reference] {
+				return
+			}
+			visiting[reference] = true
+			walk(definitions[strings.TrimPrefix(reference, "#/$defs/")], path, visiting)
+			delete(visiting, reference)
+			return
+		}
+		if kind, ok := node["type"].(string); ok && len(path) > 0 {
+			if existing, ok := fields[path]; ok && existing != kind {
+				kind = existing + "|" + kind
+			}
+			fields[path] = kind
+		}
+		if properties, ok := node["properties"].(map[string]interface{}); ok {
+			for name, property := range properties {
+				walk(property, path+"."+name, visiting)
+			}
+		}
+		if items, ok := node["items"]; ok {
+			walk(items, path+"[]", visiting)
+		}
+		if values, ok := node["additionalProperties"].(map[string]interface{}); ok {
+			walk(values, path+"{}", visiting)
+		}
+		if alternatives, ok := node["anyOf"].([]interface{}); ok {
+			for _, a := range alternatives {
+				walk(a, path, visiting)
+			}
+		}
+	}
+	walk(schema, "", map[string]bool{})
+	return fields
+}
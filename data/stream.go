@@ -0,0 +1,58 @@
+package data
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/emits-io/emits/configuration"
+)
+
+const (
+	// RecordDocument type of a stream record holding a document
+	RecordDocument = "document"
+	// RecordDiagnostic type of a stream record holding a diagnostic
+	RecordDiagnostic = "diagnostic"
+)
+
+// Record structure is a single line of a newline delimited json stream.
+type Record struct {
+	SchemaVersion string      `json:"schemaVersion"`
+	Type          string      `json:"type"`
+	Task          string      `json:"task,omitempty"`
+	Path          string      `json:"path,omitempty"`
+	Document      interface{} `json:"document,omitempty"`
+	Diagnostic    *Diagnostic `json:"diagnostic,omitempty"`
+}
+
+// Stream structure writes documents and diagnostics of a task as newline delimited json records.
+type Stream struct {
+	task    configuration.Task
+	encoder *json.Encoder
+}
+
+// NewStream returns a stream writing the records of the task to the writer.
+func NewStream(writer io.Writer, task configuration.Task) *Stream {
+	return &Stream{task: task, encoder: json.NewEncoder(writer)}
+}
+
+// Document writes a document record in the task mode shape.
+func (s *Stream) Document(document Document) error {
+	return s.encoder.Encode(Record{
+		SchemaVersion: SchemaVersion,
+		Type:          RecordDocument,
+		Task:          s.task.Name,
+		Path:          document.Key(),
+		Document:      document.shape(s.task),
+	})
+}
+
+// Diagnostic writes a diagnostic record.
+func (s *Stream) Diagnostic(diagnostic Diagnostic) error {
+	return s.encoder.Encode(Record{
+		SchemaVersion: SchemaVersion,
+		Type:          RecordDiagnostic,
+		Task:          s.task.Name,
+		Path:          diagnostic.Path,
+		Diagnostic:    &diagnostic,
+	})
+}
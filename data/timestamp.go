@@ -0,0 +1,83 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/emits-io/emits/configuration"
+)
+
+const (
+	// sourceDateEpoch environment variable referenced by the timestamp function
+	sourceDateEpoch = "SOURCE_DATE_EPOCH"
+)
+
+// ValidateTimestamp returns an error if the task timestamp source or format is not valid or the SOURCE_DATE_EPOCH of a source timestamp is not a unix timestamp; tasks are validated once before their files are read.
+func ValidateTimestamp(task configuration.Task) error {
+	options := timestampOptions(task)
+	switch options.Source {
+	case configuration.TimestampNow, configuration.TimestampNone, "":
+	case configuration.TimestampSource:
+		if epoch, ok := os.LookupEnv(sourceDateEpoch); ok {
+			if _, err := strconv.ParseInt(epoch, 10, 64); err != nil {
+				return fmt.Errorf("%s must be a unix timestamp", sourceDateEpoch)
+			}
+		}
+	default:
+		return fmt.Errorf("%s is not a valid timestamp source", options.Source)
+	}
+	switch options.Format {
+	case configuration.TimestampString, configuration.TimestampRFC3339, "":
+		return nil
+	}
+	return fmt.Errorf("%s is not a valid timestamp format", options.Format)
+}
+
+// timestampOptions returns the timestamp options of the task; a deterministic task without a timestamp source does not stamp a time.
+func timestampOptions(task configuration.Task) configuration.Timestamp {
+	options := configuration.Timestamp{}
+	if task.Timestamp != nil {
+		options = *task.Timestamp
+	}
+	if len(options.Source) == 0 && configuration.Enabled(task.Deterministic) {
+		options.Source = configuration.TimestampNone
+	}
+	return options
+}
+
+// timestamp returns the file timestamp for the task timestamp source and format; a deterministic task without a timestamp source does not stamp a time.
+func timestamp(name string, task configuration.Task) (value string, err error) {
+	options := timestampOptions(task)
+	var t time.Time
+	switch options.Source {
+	case configuration.TimestampNow, "":
+		t = time.Now()
+	case configuration.TimestampNone:
+		return "", nil
+	case configuration.TimestampSource:
+		if epoch, ok := os.LookupEnv(sourceDateEpoch); ok {
+			seconds, err := strconv.ParseInt(epoch, 10, 64)
+			if err != nil {
+				return "", fmt.Errorf("%s must be a unix timestamp", sourceDateEpoch)
+			}
+			t = time.Unix(seconds, 0)
+		} else {
+			info, err := os.Stat(name)
+			if err != nil {
+				return "", err
+			}
+			t = info.ModTime()
+		}
+	default:
+		return "", fmt.Errorf("%s is not a valid timestamp source", options.Source)
+	}
+	switch options.Format {
+	case configuration.TimestampString, "":
+		return t.UTC().String(), nil
+	case configuration.TimestampRFC3339:
+		return t.UTC().Format(time.RFC3339), nil
+	}
+	return "", fmt.Errorf("%s is not a valid timestamp format", options.Format)
+}
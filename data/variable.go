@@ -0,0 +1,99 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/emits-io/emits/configuration"
+)
+
+const (
+	// variableOpen constant referenced by the interpolate function
+	variableOpen = "${"
+	// variableClose constant referenced by the interpolate function
+	variableClose = "}"
+	// variableEnvironment prefix referenced by the lookup function
+	variableEnvironment = "env" + separator
+)
+
+// variables structure holds the values available to ${...} placeholders within a single source file.
+type variables struct {
+	source        string
+	file          File
+	configuration []Node
+	task          configuration.Task
+}
+
+// lookup returns the value of a placeholder name; built-ins take precedence over configuration nodes, task variables and allowed environment variables.
+func (v variables) lookup(name string, line int) (value string, ok bool) {
+	switch name {
+	case "file.path":
+		return filepath.ToSlash(filepath.Clean(v.source)), true
+	case "file.dir":
+		return filepath.ToSlash(v.file.Path), true
+	case "file.name":
+		return v.file.Name, true
+	case "file.extension":
+		return v.file.Extension, true
+	case "line":
+		return strconv.Itoa(line), true
+	}
+	for _, n := range v.configuration {
+		if n.Keyword == name || config+n.Keyword == name {
+			return n.Value, true
+		}
+	}
+	if value, ok := v.task.Variables[name]; ok {
+		return value, true
+	}
+	if strings.HasPrefix(name, variableEnvironment) {
+		name = name[len(variableEnvironment):]
+		for _, e := range v.task.Environment {
+			if e == name {
+				return os.LookupEnv(name)
+			}
+		}
+	}
+	return "", false
+}
+
+// interpolate replaces ${...} placeholders within the value; unresolved placeholders are left in place and reported.
+func (v variables) interpolate(value string, line int) (interpolated string, diagnostics []Diagnostic) {
+	for {
+		start := strings.Index(value, variableOpen)
+		if start < 0 {
+			break
+		}
+		end := strings.Index(value[start:], variableClose)
+		if end < 0 {
+			break
+		}
+		name := strings.TrimSpace(value[start+len(variableOpen) : start+end])
+		interpolated += value[:start]
+		if resolved, ok := v.lookup(name, line); ok {
+			interpolated += resolved
+		} else {
+			interpolated += value[start : start+end+len(variableClose)]
+			diagnostics = append(diagnostics, Diagnostic{
+				Line:    line,
+				Message: fmt.Sprintf("unresolved variable `%s`", name),
+			})
+		}
+		value = value[start+end+len(variableClose):]
+	}
+	return interpolated + value, diagnostics
+}
+
+// interpolate (recursive) resolves the placeholders of the node values and returns the diagnostics of unresolved placeholders.
+func (n *Node) interpolate(v variables) (diagnostics []Diagnostic) {
+	for i := range n.Children {
+		value, d := v.interpolate(n.Children[i].Value, n.Children[i].Line)
+		n.Children[i].Value = value
+		diagnostics = append(diagnostics, d...)
+		diagnostics = append(diagnostics, n.Children[i].interpolate(v)...)
+	}
+	return diagnostics
+}
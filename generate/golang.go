@@ -0,0 +1,261 @@
+// Package generate produces source code from emitted documents.
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/emits-io/emits/configuration"
+	"github.com/emits-io/emits/data"
+)
+
+// source structure is a node selected by a mapping with the document it was emitted from.
+type source struct {
+	node data.Node
+	path string
+}
+
+// location returns the source file and line of the node.
+func (s source) location() string {
+	return fmt.Sprintf("%s:%v", filepath.ToSlash(s.path), s.node.Line)
+}
+
+// Go returns the formatted go source of the task go mappings within the package.
+func Go(task configuration.Task, documents []data.Document, packageName string) ([]byte, error) {
+	if task.Generate == nil || len(task.Generate.Go) == 0 {
+		return nil, fmt.Errorf("%s task has no go mappings", task.Name)
+	}
+	if !isIdentifier(packageName) {
+		return nil, fmt.Errorf("%s is not a valid package name", packageName)
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("// Code generated by emits; DO NOT EDIT.\n\n")
+	buffer.WriteString(fmt.Sprintf("package %s\n", packageName))
+	declared := map[string]string{}
+	for _, m := range task.Generate.Go {
+		var sources []source
+		for _, d := range documents {
+			root := data.Node{Children: d.Data}
+			for _, n := range root.Find(m.Keyword) {
+				sources = append(sources, source{node: n, path: d.Key()})
+			}
+		}
+		var err error
+		switch m.Kind {
+		case configuration.GoConst:
+			err = goConst(&buffer, m, sources, declared)
+		case configuration.GoMap:
+			err = goMap(&buffer, m, sources, declared)
+		case configuration.GoStruct:
+			err = goStruct(&buffer, m, sources, declared)
+		default:
+			err = fmt.Errorf("%s is not a valid go mapping kind", m.Kind)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return format.Source(buffer.Bytes())
+}
+
+// declare reserves a package level identifier.
+func declare(name string, location string, declared map[string]string) error {
+	if !isIdentifier(name) {
+		return fmt.Errorf("%s is not a valid go identifier (%s)", name, location)
+	}
+	if previous, ok := declared[name]; ok {
+		return fmt.Errorf("%s is declared by %s and %s", name, previous, location)
+	}
+	declared[name] = location
+	return nil
+}
+
+// keyValue returns the key and value of a node for the mapping.
+func keyValue(m configuration.GoMapping, n data.Node) (key string, value string) {
+	if len(m.Key) > 0 {
+		key = childValue(n, m.Key)
+		value = n.Value
+	} else {
+		split := strings.SplitN(strings.TrimSpace(n.Value), " ", 2)
+		key = split[0]
+		if len(split) == 2 {
+			value = strings.TrimSpace(split[1])
+		}
+	}
+	if len(m.Value) > 0 {
+		value = childValue(n, m.Value)
+	}
+	return key, value
+}
+
+func childValue(n data.Node, keyword string) string {
+	for _, c := range n.Children {
+		if c.Keyword == keyword {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+// goConst writes the constants of the mapping; constants are integers when every value is a decimal integer, optionally negative, without a plus sign or leading zeros that would change its go literal.
+func goConst(buffer *bytes.Buffer, m configuration.GoMapping, sources []source, declared map[string]string) error {
+	numeric := true
+	for _, s := range sources {
+		_, value := keyValue(m, s.node)
+		if n, err := strconv.ParseInt(value, 10, 64); err != nil || strconv.FormatInt(n, 10) != value {
+			numeric = false
+		}
+	}
+	if len(m.Type) > 0 {
+		err := declare(m.Type, "go mapping "+m.Keyword, declared)
+		if err != nil {
+			return err
+		}
+		kind := "string"
+		if numeric && len(sources) > 0 {
+			kind = "int"
+		}
+		buffer.WriteString(fmt.Sprintf("\n// %s is generated from the %s keyword.\ntype %s %s\n", m.Type, m.Keyword, m.Type, kind))
+	}
+	buffer.WriteString("\nconst (\n")
+	for _, s := range sources {
+		key, value := keyValue(m, s.node)
+		name := m.Name + Identifier(key)
+		err := declare(name, s.location(), declared)
+		if err != nil {
+			return err
+		}
+		literal := strconv.Quote(value)
+		if numeric {
+			literal = value
+		}
+		buffer.WriteString(fmt.Sprintf("\t// %s is emitted from %s\n\t%s %s = %s\n", name, s.location(), name, m.Type, literal))
+	}
+	buffer.WriteString(")\n")
+	return nil
+}
+
+func goMap(buffer *bytes.Buffer, m configuration.GoMapping, sources []source, declared map[string]string) error {
+	err := declare(m.Name, "go mapping "+m.Keyword, declared)
+	if err != nil {
+		return err
+	}
+	buffer.WriteString(fmt.Sprintf("\n// %s is generated from the %s keyword.\nvar %s = map[string]string{\n", m.Name, m.Keyword, m.Name))
+	keys := map[string]string{}
+	for _, s := range sources {
+		key, value := keyValue(m, s.node)
+		if previous, ok := keys[key]; ok {
+			return fmt.Errorf("%s key is emitted from %s and %s", key, previous, s.location())
+		}
+		keys[key] = s.location()
+		buffer.WriteString(fmt.Sprintf("\t%s: %s, // %s\n", strconv.Quote(key), strconv.Quote(value), s.location()))
+	}
+	buffer.WriteString("}\n")
+	return nil
+}
+
+func goStruct(buffer *bytes.Buffer, m configuration.GoMapping, sources []source, declared map[string]string) error {
+	typeName := m.Type
+	if len(typeName) == 0 {
+		typeName = Identifier(m.Keyword)
+	}
+	err := declare(typeName, "go mapping "+m.Keyword, declared)
+	if err != nil {
+		return err
+	}
+	err = declare(m.Name, "go mapping "+m.Keyword, declared)
+	if err != nil {
+		return err
+	}
+	var keywords []string
+	repeated := map[string]bool{}
+	fields := map[string]string{}
+	for _, s := range sources {
+		count := map[string]int{}
+		for _, c := range s.node.Children {
+			if len(c.Keyword) == 0 {
+				continue
+			}
+			field := Identifier(c.Keyword)
+			if keyword, ok := fields[field]; ok && keyword != c.Keyword {
+				return fmt.Errorf("%s and %s keywords generate the same %s field (%s)", keyword, c.Keyword, field, s.location())
+			}
+			if _, ok := fields[field]; !ok {
+				fields[field] = c.Keyword
+				keywords = append(keywords, c.Keyword)
+			}
+			count[c.Keyword]++
+			if count[c.Keyword] > 1 {
+				repeated[c.Keyword] = true
+			}
+		}
+	}
+	_, hasValue := fields["Value"]
+	buffer.WriteString(fmt.Sprintf("\n// %s is generated from the %s keyword.\ntype %s struct {\n", typeName, m.Keyword, typeName))
+	if !hasValue {
+		buffer.WriteString("\tValue string\n")
+	}
+	for _, k := range keywords {
+		kind := "string"
+		if repeated[k] {
+			kind = "[]string"
+		}
+		buffer.WriteString(fmt.Sprintf("\t%s %s\n", Identifier(k), kind))
+	}
+	buffer.WriteString("}\n")
+	buffer.WriteString(fmt.Sprintf("\n// %s is generated from the %s keyword.\nvar %s = []%s{\n", m.Name, m.Keyword, m.Name, typeName))
+	for _, s := range sources {
+		buffer.WriteString(fmt.Sprintf("\t// %s\n\t{\n", s.location()))
+		if !hasValue {
+			buffer.WriteString(fmt.Sprintf("\t\tValue: %s,\n", strconv.Quote(s.node.Value)))
+		}
+		values := map[string][]string{}
+		for _, c := range s.node.Children {
+			if len(c.Keyword) > 0 {
+				values[c.Keyword] = append(values[c.Keyword], strconv.Quote(c.Value))
+			}
+		}
+		for _, k := range keywords {
+			if len(values[k]) == 0 {
+				continue
+			}
+			if repeated[k] {
+				buffer.WriteString(fmt.Sprintf("\t\t%s: []string{%s},\n", Identifier(k), strings.Join(values[k], ", ")))
+			} else {
+				buffer.WriteString(fmt.Sprintf("\t\t%s: %s,\n", Identifier(k), values[k][0]))
+			}
+		}
+		buffer.WriteString("\t},\n")
+	}
+	buffer.WriteString("}\n")
+	return nil
+}
+
+// Identifier returns an exported go identifier of the text; words are separated by characters that are not letters or digits.
+func Identifier(text string) (identifier string) {
+	words := strings.FieldsFunc(text, func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+	})
+	for _, w := range words {
+		runes := []rune(w)
+		identifier += string(unicode.ToUpper(runes[0])) + string(runes[1:])
+	}
+	if len(identifier) > 0 && unicode.IsDigit([]rune(identifier)[0]) {
+		identifier = "N" + identifier
+	}
+	return identifier
+}
+
+func isIdentifier(name string) bool {
+	for i, c := range name {
+		if !unicode.IsLetter(c) && c != '_' && (i == 0 || !unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return len(name) > 0
+}
@@ -0,0 +1,65 @@
+// Package markup encodes values as yaml and toml through json so the json field names are kept.
+package markup
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLNode returns the block style yaml document node of the value; the json key order is kept.
+func YAMLNode(value interface{}) (*yaml.Node, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	err = yaml.Unmarshal(data, &node)
+	if err != nil {
+		return nil, err
+	}
+	blockStyle(&node)
+	return &node, nil
+}
+
+// EncodeYAML returns the yaml of the node indented by two spaces.
+func EncodeYAML(node *yaml.Node) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	err := encoder.Encode(node)
+	if err == nil {
+		err = encoder.Close()
+	}
+	return buffer.Bytes(), err
+}
+
+// EncodeTOML returns the toml of the value; json numbers are kept as written.
+func EncodeTOML(value interface{}) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var table map[string]interface{}
+	err = decoder.Decode(&table)
+	if err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	encoder := toml.NewEncoder(&buffer)
+	encoder.Indent = ""
+	err = encoder.Encode(table)
+	return buffer.Bytes(), err
+}
+
+// blockStyle (recursive) clears the flow and quoting styles json decoding leaves on yaml nodes.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, c := range node.Content {
+		blockStyle(c)
+	}
+}
@@ -8,6 +8,10 @@ import (
 )
 
 func main() {
+	if err := command.Configure(); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
 	arg := "help"
 	if len(os.Args) > 1 {
 		arg = os.Args[1]
@@ -0,0 +1,170 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/emits-io/emits/configuration"
+	"github.com/emits-io/emits/data"
+)
+
+// Man returns a roff man(7) page per top-level command node; the command value is the command name followed by a one line summary.
+//
+// Options are annotated as the option flags and arguments followed by a description; nested command nodes are listed as commands of the page.
+func Man(task configuration.Task, documents []data.Document) (pages []Page, err error) {
+	options := configuration.Man{}
+	if task.Render != nil && task.Render.Man != nil {
+		options = *task.Render.Man
+	}
+	if len(options.Section) == 0 {
+		options.Section = "1"
+	}
+	keyword := manKeywords(options.Keyword)
+	names := map[string]string{}
+	for _, d := range documents {
+		for _, n := range d.Data {
+			if n.Keyword != keyword.Command {
+				continue
+			}
+			location := fmt.Sprintf("%s:%v", filepath.ToSlash(d.Key()), n.Line)
+			name, summary := manCommand(n)
+			if len(name) == 0 {
+				return nil, fmt.Errorf("%s command name is required", location)
+			}
+			if previous, ok := names[name]; ok {
+				return nil, fmt.Errorf("%s %s command is already declared at %s", location, name, previous)
+			}
+			names[name] = location
+			pages = append(pages, Page{
+				Path: filepath.Join("man"+options.Section, name+"."+options.Section),
+				Data: manPage(task, options.Section, name, summary, n, keyword),
+			})
+		}
+	}
+	return pages, nil
+}
+
+// manKeywords returns the keyword mapping with the field names as defaults.
+func manKeywords(k configuration.ManKeyword) configuration.ManKeyword {
+	defaults := map[*string]string{
+		&k.Command:     "command",
+		&k.Synopsis:    "synopsis",
+		&k.Description: "description",
+		&k.Option:      "option",
+		&k.Example:     "example",
+	}
+	for field, value := range defaults {
+		if len(*field) == 0 {
+			*field = value
+		}
+	}
+	return k
+}
+
+func manCommand(n data.Node) (name string, summary string) {
+	split := strings.SplitN(strings.TrimSpace(n.Value), " ", 2)
+	name = split[0]
+	if len(split) == 2 {
+		summary = strings.TrimSpace(split[1])
+	}
+	return name, summary
+}
+
+func manPage(task configuration.Task, section string, name string, summary string, command data.Node, keyword configuration.ManKeyword) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf(".TH %s %s \"\" %s\n", roffQuote(strings.ToUpper(name)), section, roffQuote(task.Name)))
+	buffer.WriteString(".SH NAME\n")
+	if len(summary) > 0 {
+		buffer.WriteString(fmt.Sprintf("%s \\- %s\n", roffEscape(name), roffEscape(summary)))
+	} else {
+		buffer.WriteString(roffEscape(name) + "\n")
+	}
+	sections := []struct {
+		title   string
+		keyword string
+	}{
+		{"SYNOPSIS", keyword.Synopsis},
+		{"DESCRIPTION", keyword.Description},
+		{"OPTIONS", keyword.Option},
+		{"COMMANDS", keyword.Command},
+		{"EXAMPLES", keyword.Example},
+	}
+	for _, s := range sections {
+		var nodes []data.Node
+		for _, c := range command.Children {
+			if c.Keyword == s.keyword {
+				nodes = append(nodes, c)
+			}
+		}
+		if len(nodes) == 0 {
+			continue
+		}
+		buffer.WriteString(".SH " + s.title + "\n")
+		for _, n := range nodes {
+			switch s.keyword {
+			case keyword.Synopsis:
+				buffer.WriteString(fmt.Sprintf(".B %s\n%s\n.br\n", roffEscape(name), roffEscape(n.Value)))
+			case keyword.Option:
+				spec, description := manOption(n.Value)
+				buffer.WriteString(fmt.Sprintf(".TP\n.B %s\n%s\n", roffEscape(spec), roffParagraphs(description, ".IP")))
+				for _, c := range n.Children {
+					buffer.WriteString(fmt.Sprintf(".IP\n%s\n", roffParagraphs(c.Value, ".IP")))
+				}
+			case keyword.Command:
+				subcommand, subcommandSummary := manCommand(n)
+				buffer.WriteString(fmt.Sprintf(".TP\n.B %s\n%s\n", roffEscape(subcommand), roffParagraphs(subcommandSummary, ".IP")))
+			case keyword.Example:
+				buffer.WriteString(".PP\n.RS\n.nf\n")
+				for _, line := range strings.Split(n.Value, "\n") {
+					buffer.WriteString(roffEscape(line) + "\n")
+				}
+				buffer.WriteString(".fi\n.RE\n")
+			default:
+				buffer.WriteString(".PP\n" + roffParagraphs(n.Value, ".PP") + "\n")
+			}
+		}
+	}
+	return buffer.Bytes()
+}
+
+// manOption splits an option value into the leading flags and arguments and the description.
+func manOption(value string) (spec string, description string) {
+	fields := strings.Fields(value)
+	i := 0
+	for i < len(fields) {
+		f := fields[i]
+		if !strings.HasPrefix(f, "-") && !strings.HasPrefix(f, "<") && !strings.HasPrefix(f, "[") {
+			break
+		}
+		i++
+	}
+	return strings.Join(fields[:i], " "), strings.Join(fields[i:], " ")
+}
+
+// roffParagraphs escapes the text and separates blank line delimited paragraphs by the macro; indented paragraphs of a .TP or .IP item are separated by .IP.
+func roffParagraphs(text string, macro string) string {
+	var paragraphs []string
+	for _, p := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		paragraphs = append(paragraphs, roffEscape(strings.TrimSpace(p)))
+	}
+	return strings.Join(paragraphs, "\n"+macro+"\n")
+}
+
+// roffEscape escapes backslashes, hyphens and lines starting with a control character.
+func roffEscape(text string) string {
+	text = strings.Replace(text, "\\", "\\e", -1)
+	text = strings.Replace(text, "-", "\\-", -1)
+	lines := strings.Split(text, "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(l, ".") || strings.HasPrefix(l, "'") {
+			lines[i] = "\\&" + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func roffQuote(text string) string {
+	return "\"" + strings.Replace(roffEscape(text), "\"", "\\(dq", -1) + "\""
+}
@@ -0,0 +1,131 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/emits-io/emits/configuration"
+	"github.com/emits-io/emits/data"
+)
+
+const (
+	// markdownExtension of the rendered markdown pages
+	markdownExtension = ".md"
+	// markdownIndex page name of the table of contents
+	markdownIndex = "index" + markdownExtension
+)
+
+// Markdown returns a markdown page per document and a table of contents page.
+func Markdown(task configuration.Task, documents []data.Document) (pages []Page) {
+	options := configuration.Markdown{}
+	if task.Render != nil && task.Render.Markdown != nil {
+		options = *task.Render.Markdown
+	}
+	var index bytes.Buffer
+	index.WriteString(fmt.Sprintf("# %s\n\n", EscapeMarkdown(task.Name)))
+	if len(task.Description) > 0 {
+		index.WriteString(fmt.Sprintf("%s\n\n", EscapeMarkdown(task.Description)))
+	}
+	for _, d := range documents {
+		path := pagePath(d.Key()) + markdownExtension
+		index.WriteString(fmt.Sprintf("- [%s](%s)\n", EscapeMarkdown(filepath.ToSlash(d.Key())), path))
+
+		var page bytes.Buffer
+		page.WriteString(fmt.Sprintf("# %s\n", EscapeMarkdown(filepath.ToSlash(d.Key()))))
+		markdownNodes(&page, d.Data, 0, d.File.Extension, options)
+		pages = append(pages, Page{Path: path, Data: page.Bytes()})
+	}
+	return append([]Page{{Path: markdownIndex, Data: index.Bytes()}}, pages...)
+}
+
+// markdownStyle returns the configured keyword style; nodes with children default to headings, multiline values to paragraphs, source lines to code and everything else to list items.
+func markdownStyle(n data.Node, options configuration.Markdown) string {
+	if style, ok := options.Keyword[n.Keyword]; ok && len(n.Keyword) > 0 {
+		return style
+	}
+	if len(n.Keyword) == 0 {
+		return configuration.MarkdownCode
+	}
+	if len(n.Children) > 0 {
+		return configuration.MarkdownHeading
+	}
+	if strings.Contains(n.Value, "\n") {
+		return configuration.MarkdownParagraph
+	}
+	return configuration.MarkdownList
+}
+
+// markdownNodes (recursive) writes the nodes; consecutive list items and code lines are grouped.
+func markdownNodes(buffer *bytes.Buffer, nodes []data.Node, depth int, language string, options configuration.Markdown) {
+	previous := ""
+	for _, n := range nodes {
+		style := markdownStyle(n, options)
+		if style == configuration.MarkdownHidden {
+			continue
+		}
+		if previous == configuration.MarkdownCode && style != configuration.MarkdownCode {
+			buffer.WriteString("```\n")
+		}
+		if style != previous || style != configuration.MarkdownList && style != configuration.MarkdownCode {
+			buffer.WriteString("\n")
+		}
+		switch style {
+		case configuration.MarkdownHeading:
+			level := depth + 2
+			if level > 6 {
+				level = 6
+			}
+			title := n.Value
+			if len(title) == 0 {
+				title = n.Keyword
+			}
+			buffer.WriteString(fmt.Sprintf("%s %s%s\n", strings.Repeat("#", level), EscapeMarkdown(title), markdownFlags(n.Flags)))
+		case configuration.MarkdownParagraph:
+			if len(n.Keyword) > 0 {
+				buffer.WriteString(fmt.Sprintf("**%s**%s\n", EscapeMarkdown(n.Keyword), markdownFlags(n.Flags)))
+			}
+			if len(n.Value) > 0 {
+				buffer.WriteString("\n" + n.Value + "\n")
+			}
+		case configuration.MarkdownCode:
+			if previous != configuration.MarkdownCode {
+				buffer.WriteString("```" + language + "\n")
+			}
+			buffer.WriteString(n.Value + "\n")
+		default:
+			buffer.WriteString(fmt.Sprintf("- **%s**%s %s\n", EscapeMarkdown(n.Keyword), markdownFlags(n.Flags), EscapeMarkdown(n.Value)))
+		}
+		previous = style
+		if len(n.Children) > 0 {
+			if style == configuration.MarkdownCode {
+				buffer.WriteString("```\n")
+			}
+			markdownNodes(buffer, n.Children, depth+1, language, options)
+			previous = ""
+		}
+	}
+	if previous == configuration.MarkdownCode {
+		buffer.WriteString("```\n")
+	}
+}
+
+func markdownFlags(flags []string) (value string) {
+	for _, f := range flags {
+		value += fmt.Sprintf(" `%s`", f)
+	}
+	return value
+}
+
+// EscapeMarkdown returns the text with markdown control characters escaped; block characters are only escaped at the start of the text.
+func EscapeMarkdown(text string) string {
+	var buffer bytes.Buffer
+	for i, c := range text {
+		if strings.ContainsRune("\\`*_[]<>|", c) || i == 0 && strings.ContainsRune("#+-=", c) {
+			buffer.WriteRune('\\')
+		}
+		buffer.WriteRune(c)
+	}
+	return buffer.String()
+}
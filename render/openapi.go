@@ -0,0 +1,274 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/emits-io/emits/configuration"
+	"github.com/emits-io/emits/data"
+)
+
+const (
+	// openAPIVersion of the rendered document
+	openAPIVersion = "3.0.3"
+	// openAPIFile name of the rendered document
+	openAPIFile = "openapi.json"
+	// openAPIRequired flag marks a parameter as required
+	openAPIRequired = "required"
+	// openAPIDeprecated flag marks an operation or parameter as deprecated
+	openAPIDeprecated = "deprecated"
+)
+
+var (
+	openAPIMethods   = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+	openAPILocations = []string{"query", "header", "path", "cookie"}
+	openAPITypes     = []string{"string", "integer", "number", "boolean", "array", "object"}
+	openAPIPath      = regexp.MustCompile(`{([^}]+)}`)
+	openAPIStatus    = regexp.MustCompile(`^([1-5][0-9X]{2}|default)$`)
+)
+
+// openAPIDocument structure is the rendered OpenAPI document.
+type openAPIDocument struct {
+	OpenAPI string                                 `json:"openapi"`
+	Info    openAPIInfo                            `json:"info"`
+	Paths   map[string]map[string]openAPIOperation `json:"paths"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIOperation struct {
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+	Source      string                     `json:"x-emits-source"`
+}
+
+type openAPIParameter struct {
+	Name        string        `json:"name"`
+	In          string        `json:"in"`
+	Description string        `json:"description,omitempty"`
+	Required    bool          `json:"required,omitempty"`
+	Deprecated  bool          `json:"deprecated,omitempty"`
+	Schema      openAPISchema `json:"schema"`
+}
+
+// openAPISchema structure is a parameter schema; array parameters have string items.
+type openAPISchema struct {
+	Type  string         `json:"type"`
+	Items *openAPISchema `json:"items,omitempty"`
+}
+
+type openAPIResponse struct {
+	Description string `json:"description"`
+}
+
+// OpenAPI returns an OpenAPI document assembled from the route annotations of every document; the nodes following a route node up to the next route node belong to its operation.
+//
+// Parameters are annotated as `name in [type] [description]` and responses as `status description`; an error lists every invalid operation with its source file and line.
+func OpenAPI(task configuration.Task, documents []data.Document) (pages []Page, err error) {
+	options := configuration.OpenAPI{}
+	if task.Render != nil && task.Render.OpenAPI != nil {
+		options = *task.Render.OpenAPI
+	}
+	keyword := openAPIKeywords(options.Keyword)
+	document := openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info:    openAPIInfo{Title: options.Title, Version: options.Version},
+		Paths:   map[string]map[string]openAPIOperation{},
+	}
+	if len(document.Info.Title) == 0 {
+		document.Info.Title = task.Name
+	}
+	if len(document.Info.Version) == 0 {
+		document.Info.Version = "0.0.0"
+	}
+	var problems []string
+	for _, d := range documents {
+		for _, route := range openAPIRoutes(d.Data, keyword.Route) {
+			location := fmt.Sprintf("%s:%v", filepath.ToSlash(d.Key()), route[0].Line)
+			path, method, operation, issues := openAPIOperationOf(route, keyword)
+			operation.Source = location
+			if len(issues) == 0 {
+				if _, ok := document.Paths[path][method]; ok {
+					issues = append(issues, fmt.Sprintf("duplicate %s %s operation; first declared at %s", strings.ToUpper(method), path, document.Paths[path][method].Source))
+				}
+			}
+			for _, i := range issues {
+				problems = append(problems, fmt.Sprintf("%s %s", location, i))
+			}
+			if len(issues) > 0 {
+				continue
+			}
+			if document.Paths[path] == nil {
+				document.Paths[path] = map[string]openAPIOperation{}
+			}
+			document.Paths[path][method] = operation
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid openapi operations:\n%s", strings.Join(problems, "\n"))
+	}
+	output, err := json.MarshalIndent(document, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return []Page{{Path: openAPIFile, Data: output}}, nil
+}
+
+// openAPIKeywords returns the keyword mapping with the field names as defaults.
+func openAPIKeywords(k configuration.OpenAPIKeyword) configuration.OpenAPIKeyword {
+	defaults := map[*string]string{
+		&k.Route:       "route",
+		&k.Method:      "method",
+		&k.Summary:     "summary",
+		&k.Description: "description",
+		&k.Tag:         "tag",
+		&k.Param:       "param",
+		&k.Response:    "response",
+	}
+	for field, value := range defaults {
+		if len(*field) == 0 {
+			*field = value
+		}
+	}
+	return k
+}
+
+// openAPIRoutes (recursive) returns each route node followed by its children and the sibling nodes up to the next route node; routes nested within those nodes are returned after the route.
+func openAPIRoutes(nodes []data.Node, route string) (routes [][]data.Node) {
+	for i := 0; i < len(nodes); i++ {
+		if nodes[i].Keyword != route {
+			routes = append(routes, openAPIRoutes(nodes[i].Children, route)...)
+			continue
+		}
+		operation := []data.Node{nodes[i]}
+		fields, nested := openAPIFields(nodes[i].Children, route)
+		operation = append(operation, fields...)
+		for i+1 < len(nodes) && nodes[i+1].Keyword != route {
+			i++
+			operation = append(operation, nodes[i])
+			fields, more := openAPIFields(nodes[i].Children, route)
+			operation = append(operation, fields...)
+			nested = append(nested, more...)
+		}
+		routes = append(append(routes, operation), nested...)
+	}
+	return routes
+}
+
+// openAPIFields returns the nodes of an operation up to the first route node and the routes within and after them.
+func openAPIFields(nodes []data.Node, route string) (fields []data.Node, routes [][]data.Node) {
+	for i, n := range nodes {
+		if n.Keyword == route {
+			return fields, append(routes, openAPIRoutes(nodes[i:], route)...)
+		}
+		fields = append(fields, n)
+		routes = append(routes, openAPIRoutes(n.Children, route)...)
+	}
+	return fields, routes
+}
+
+// openAPIOperationOf returns the path, method and operation of a route; a route value may start with the method.
+func openAPIOperationOf(route []data.Node, keyword configuration.OpenAPIKeyword) (path string, method string, operation openAPIOperation, issues []string) {
+	operation.Responses = map[string]openAPIResponse{}
+	fields := strings.Fields(route[0].Value)
+	if len(fields) == 2 {
+		method, path = strings.ToLower(fields[0]), fields[1]
+	} else if len(fields) == 1 {
+		path = fields[0]
+	}
+	operation.Deprecated = hasFlag(openAPIDeprecated, route[0])
+	for _, n := range route[1:] {
+		switch n.Keyword {
+		case keyword.Method:
+			method = strings.ToLower(strings.TrimSpace(n.Value))
+		case keyword.Summary:
+			operation.Summary = n.Value
+		case keyword.Description:
+			operation.Description = n.Value
+		case keyword.Tag:
+			operation.Tags = append(operation.Tags, strings.TrimSpace(n.Value))
+		case keyword.Param:
+			parameter, issue := openAPIParameterOf(n)
+			if len(issue) > 0 {
+				issues = append(issues, issue)
+				continue
+			}
+			operation.Parameters = append(operation.Parameters, parameter)
+		case keyword.Response:
+			split := strings.SplitN(strings.TrimSpace(n.Value), " ", 2)
+			if !openAPIStatus.MatchString(split[0]) {
+				issues = append(issues, fmt.Sprintf("line %v: %s is not a valid response status", n.Line, strconv.Quote(split[0])))
+				continue
+			}
+			response := openAPIResponse{Description: split[0]}
+			if len(split) == 2 {
+				response.Description = strings.TrimSpace(split[1])
+			}
+			operation.Responses[split[0]] = response
+		}
+	}
+	if !strings.HasPrefix(path, "/") {
+		issues = append(issues, fmt.Sprintf("route path %s must start with /", strconv.Quote(path)))
+	}
+	if !contains(openAPIMethods, method) {
+		issues = append(issues, fmt.Sprintf("%s is not a valid method", strconv.Quote(method)))
+	}
+	if len(operation.Responses) == 0 {
+		issues = append(issues, "at least one response is required")
+	}
+	for _, match := range openAPIPath.FindAllStringSubmatch(path, -1) {
+		declared := false
+		for _, p := range operation.Parameters {
+			declared = declared || p.In == "path" && p.Name == match[1]
+		}
+		if !declared {
+			issues = append(issues, fmt.Sprintf("path parameter %s is not declared", match[1]))
+		}
+	}
+	return path, method, operation, issues
+}
+
+// openAPIParameterOf returns the parameter of a `name in [type] [description]` node; path parameters are always required.
+func openAPIParameterOf(n data.Node) (parameter openAPIParameter, issue string) {
+	fields := strings.Fields(n.Value)
+	if len(fields) < 2 || !contains(openAPILocations, fields[1]) {
+		return parameter, fmt.Sprintf("line %v: parameter must be annotated as `name in [type] [description]` where in is one of %s", n.Line, strings.Join(openAPILocations, ", "))
+	}
+	parameter = openAPIParameter{
+		Name:       fields[0],
+		In:         fields[1],
+		Required:   fields[1] == "path" || hasFlag(openAPIRequired, n),
+		Deprecated: hasFlag(openAPIDeprecated, n),
+		Schema:     openAPISchema{Type: "string"},
+	}
+	description := fields[2:]
+	if len(description) > 0 && contains(openAPITypes, description[0]) {
+		parameter.Schema.Type = description[0]
+		description = description[1:]
+	}
+	if parameter.Schema.Type == "array" {
+		parameter.Schema.Items = &openAPISchema{Type: "string"}
+	}
+	parameter.Description = strings.Join(description, " ")
+	return parameter, ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+// Package render converts emitted documents into other document formats.
+package render
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Page structure is a rendered file relative to the render output directory.
+type Page struct {
+	Path string
+	Data []byte
+}
+
+// Write the pages to persistant storage within the directory and return their paths.
+//
+// Paths never leave the directory; leading parent directories of a page path are removed.
+func Write(directory string, pages []Page) (files []string, err error) {
+	for _, p := range pages {
+		file := filepath.Join(directory, filepath.FromSlash(pagePath(p.Path)))
+		err = os.MkdirAll(filepath.Dir(file), os.ModePerm)
+		if err != nil {
+			return files, err
+		}
+		err = ioutil.WriteFile(file, p.Data, 0644)
+		if err != nil {
+			return files, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// pagePath returns the slash separated page path cleaned within the render output directory.
+func pagePath(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/")
+}
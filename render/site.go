@@ -0,0 +1,204 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/emits-io/emits/configuration"
+	"github.com/emits-io/emits/data"
+)
+
+// SitePage structure is the value the site layout is executed with.
+type SitePage struct {
+	Site        string
+	Title       string
+	Root        string
+	Task        configuration.Task
+	Navigation  []SiteLink
+	Keywords    []SiteLink
+	Document    data.Document
+	Occurrences []SiteOccurrence
+}
+
+// SiteLink structure is a navigation entry; directories have no url.
+type SiteLink struct {
+	Title string
+	Path  string
+	URL   string
+	Depth int
+}
+
+// SiteOccurrence structure is a keyword node linked from a keyword page.
+type SiteOccurrence struct {
+	Path  string
+	Line  int
+	Value string
+	URL   string
+}
+
+// siteBranch structure is the value the recursive nodes template is executed with.
+type siteBranch struct {
+	Root  string
+	Nodes []data.Node
+}
+
+// siteSearch structure is an entry of the client-side search data.
+type siteSearch struct {
+	Keyword string `json:"keyword"`
+	Value   string `json:"value"`
+	URL     string `json:"url"`
+}
+
+// Site returns a self-contained static site with a page per document, a page per keyword, a home page and client-side search data.
+func Site(task configuration.Task, documents []data.Document) (pages []Page, err error) {
+	options := configuration.Site{}
+	if task.Render != nil && task.Render.Site != nil {
+		options = *task.Render.Site
+	}
+	if len(options.Title) == 0 {
+		options.Title = task.Name
+	}
+	files, err := siteTheme(options.Theme)
+	if err != nil {
+		return nil, err
+	}
+	layout := func(content string) (*template.Template, error) {
+		tmpl, err := template.New("layout.html").Funcs(template.FuncMap{
+			"branch": func(root string, nodes []data.Node) siteBranch {
+				return siteBranch{Root: root, Nodes: nodes}
+			},
+		}).Parse(files["layout.html"])
+		if err != nil {
+			return nil, err
+		}
+		return tmpl.New(content).Parse(files[content])
+	}
+
+	documents = append([]data.Document(nil), documents...)
+	sort.SliceStable(documents, func(i, j int) bool {
+		return filepath.ToSlash(documents[i].Key()) < filepath.ToSlash(documents[j].Key())
+	})
+	navigation := siteNavigation(documents)
+	occurrences := map[string][]SiteOccurrence{}
+	var search []siteSearch
+	for _, d := range documents {
+		url := siteDocumentURL(d)
+		for _, n := range walk(d) {
+			if len(n.Keyword) == 0 {
+				continue
+			}
+			occurrences[n.Keyword] = append(occurrences[n.Keyword], SiteOccurrence{
+				Path:  filepath.ToSlash(d.Key()),
+				Line:  n.Line,
+				Value: n.Value,
+				URL:   url + "#" + n.ID,
+			})
+			search = append(search, siteSearch{Keyword: n.Keyword, Value: n.Value, URL: url + "#" + n.ID})
+		}
+	}
+	var keywords []SiteLink
+	for k := range occurrences {
+		keywords = append(keywords, SiteLink{Title: k, Path: k, URL: "keywords/" + k + ".html"})
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		return keywords[i].Title < keywords[j].Title
+	})
+
+	page := func(name string, url string, value SitePage) error {
+		tmpl, err := layout(name)
+		if err != nil {
+			return err
+		}
+		value.Site = options.Title
+		value.Task = task
+		value.Navigation = navigation
+		value.Keywords = keywords
+		value.Root = strings.Repeat("../", strings.Count(url, "/"))
+		var buffer bytes.Buffer
+		err = tmpl.ExecuteTemplate(&buffer, "layout.html", value)
+		if err != nil {
+			return err
+		}
+		pages = append(pages, Page{Path: filepath.FromSlash(url), Data: buffer.Bytes()})
+		return nil
+	}
+
+	err = page("home.html", "index.html", SitePage{Title: options.Title})
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range documents {
+		err = page("document.html", siteDocumentURL(d), SitePage{Title: filepath.ToSlash(d.Key()), Document: d})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, k := range keywords {
+		err = page("keyword.html", k.URL, SitePage{Title: k.Title, Occurrences: occurrences[k.Title]})
+		if err != nil {
+			return nil, err
+		}
+	}
+	searchData, err := json.Marshal(search)
+	if err != nil {
+		return nil, err
+	}
+	return append(pages,
+		Page{Path: "style.css", Data: []byte(files["style.css"])},
+		Page{Path: "search.js", Data: []byte(files["search.js"])},
+		Page{Path: "search-data.js", Data: []byte("window.emitsSearch = " + string(searchData) + ";\n")},
+	), nil
+}
+
+// siteTheme returns the embedded theme files overridden by the files of the theme directory.
+func siteTheme(directory string) (files map[string]string, err error) {
+	files = map[string]string{}
+	for name, content := range theme {
+		files[name] = content
+		if len(directory) == 0 {
+			continue
+		}
+		read, err := ioutil.ReadFile(filepath.Join(directory, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		files[name] = string(read)
+	}
+	return files, nil
+}
+
+func siteDocumentURL(d data.Document) string {
+	return "files/" + pagePath(d.Key()) + ".html"
+}
+
+// siteNavigation returns the document links nested under their directories; documents must be sorted by path.
+func siteNavigation(documents []data.Document) (links []SiteLink) {
+	var directories []string
+	for _, d := range documents {
+		key := filepath.ToSlash(d.Key())
+		parts := strings.Split(path.Dir(key), "/")
+		if parts[0] == "." {
+			parts = nil
+		}
+		common := 0
+		for common < len(parts) && common < len(directories) && parts[common] == directories[common] {
+			common++
+		}
+		for i := common; i < len(parts); i++ {
+			links = append(links, SiteLink{Title: parts[i], Path: strings.Join(parts[:i+1], "/"), Depth: i})
+		}
+		directories = parts
+		links = append(links, SiteLink{Title: path.Base(key), Path: key, URL: siteDocumentURL(d), Depth: len(parts)})
+	}
+	return links
+}
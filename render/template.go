@@ -0,0 +1,170 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/emits-io/emits/configuration"
+	"github.com/emits-io/emits/data"
+)
+
+const (
+	// templatePath placeholder of a file scope template output name
+	templatePath = "{path}"
+)
+
+// TemplateData structure is the value a template is executed with; Document is set for the file scope and Documents for the task scope.
+type TemplateData struct {
+	Task      configuration.Task
+	Index     configuration.Index
+	Document  data.Document
+	Documents []data.Document
+}
+
+type executor interface {
+	Execute(writer io.Writer, data interface{}) error
+}
+
+// Templates returns the pages of every task template.
+func Templates(task configuration.Task, documents []data.Document) (pages []Page, err error) {
+	if task.Render == nil || len(task.Render.Template) == 0 {
+		return nil, fmt.Errorf("%s task has no render templates", task.Name)
+	}
+	index := configuration.Index{}
+	for _, d := range documents {
+		index.Files = append(index.Files, filepath.ToSlash(d.Key()))
+	}
+	for _, t := range task.Render.Template {
+		if t.Scope != configuration.TemplateFile && t.Scope != configuration.TemplateTask && len(t.Scope) > 0 {
+			return pages, fmt.Errorf("%s is not a valid template scope", t.Scope)
+		}
+		tmpl, err := parseTemplate(t)
+		if err != nil {
+			return pages, err
+		}
+		if t.Scope == configuration.TemplateTask {
+			page, err := executeTemplate(tmpl, t.Output, TemplateData{Task: task, Index: index, Documents: documents})
+			if err != nil {
+				return pages, err
+			}
+			pages = append(pages, page)
+			continue
+		}
+		output := t.Output
+		if !strings.Contains(output, templatePath) {
+			output = templatePath + output
+		}
+		for _, d := range documents {
+			page, err := executeTemplate(tmpl, strings.Replace(output, templatePath, pagePath(d.Key()), -1), TemplateData{Task: task, Index: index, Document: d})
+			if err != nil {
+				return pages, err
+			}
+			pages = append(pages, page)
+		}
+	}
+	return pages, nil
+}
+
+func parseTemplate(t configuration.Template) (executor, error) {
+	read, err := ioutil.ReadFile(t.File)
+	if err != nil {
+		return nil, err
+	}
+	name := filepath.Base(t.File)
+	switch t.Engine {
+	case configuration.TemplateHTML:
+		return htmltemplate.New(name).Funcs(htmltemplate.FuncMap(Functions())).Parse(string(read))
+	case configuration.TemplateText, "":
+		return texttemplate.New(name).Funcs(texttemplate.FuncMap(Functions())).Parse(string(read))
+	}
+	return nil, fmt.Errorf("%s is not a valid template engine", t.Engine)
+}
+
+func executeTemplate(tmpl executor, path string, value TemplateData) (page Page, err error) {
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, value)
+	return Page{Path: filepath.FromSlash(path), Data: buffer.Bytes()}, err
+}
+
+// Functions returns the helper functions available to templates.
+func Functions() map[string]interface{} {
+	return map[string]interface{}{
+		"walk":     walk,
+		"find":     find,
+		"first":    first,
+		"flags":    flags,
+		"hasFlag":  hasFlag,
+		"join":     strings.Join,
+		"split":    strings.Split,
+		"lower":    strings.ToLower,
+		"upper":    strings.ToUpper,
+		"trim":     strings.TrimSpace,
+		"markdown": EscapeMarkdown,
+	}
+}
+
+// nodes returns the nodes of a document, node or node slice.
+func nodes(value interface{}) []data.Node {
+	switch v := value.(type) {
+	case data.Document:
+		return v.Data
+	case *data.Document:
+		return v.Data
+	case data.Node:
+		return v.Children
+	case *data.Node:
+		return v.Children
+	case []data.Node:
+		return v
+	}
+	return nil
+}
+
+// walk (recursive) returns every node of the tree in document order.
+func walk(value interface{}) (walked []data.Node) {
+	for _, n := range nodes(value) {
+		walked = append(walked, n)
+		walked = append(walked, walk(n)...)
+	}
+	return walked
+}
+
+// find returns every node of the tree with the keyword.
+func find(keyword string, value interface{}) (found []data.Node) {
+	for _, n := range walk(value) {
+		if n.Keyword == keyword {
+			found = append(found, n)
+		}
+	}
+	return found
+}
+
+// first returns the first node of the tree with the keyword; or an empty node.
+func first(keyword string, value interface{}) data.Node {
+	found := find(keyword, value)
+	if len(found) > 0 {
+		return found[0]
+	}
+	return data.Node{}
+}
+
+// flags returns the node flags.
+func flags(node data.Node) []string {
+	return node.Flags
+}
+
+// hasFlag returns true if the node has the flag.
+func hasFlag(flag string, node data.Node) bool {
+	for _, f := range node.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
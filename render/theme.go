@@ -0,0 +1,110 @@
+package render
+
+// theme holds the embedded site theme files; a task site theme directory overrides them by file name.
+var theme = map[string]string{
+	"layout.html": `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} · {{.Site}}</title>
+<link rel="stylesheet" href="{{.Root}}style.css">
+</head>
+<body>
+<nav>
+<a class="site" href="{{.Root}}index.html">{{.Site}}</a>
+<input id="search" type="search" placeholder="Search" autocomplete="off">
+<ul id="results"></ul>
+<ul class="files">
+{{- range .Navigation}}
+<li style="padding-left: {{.Depth}}em">{{if .URL}}<a href="{{$.Root}}{{.URL}}">{{.Title}}</a>{{else}}<span>{{.Title}}/</span>{{end}}</li>
+{{- end}}
+</ul>
+</nav>
+<main>
+{{template "content" .}}
+</main>
+<script>var root = "{{.Root}}";</script>
+<script src="{{.Root}}search-data.js"></script>
+<script src="{{.Root}}search.js"></script>
+</body>
+</html>
+`,
+	"home.html": `{{define "content"}}
+<h1>{{.Site}}</h1>
+{{if .Task.Description}}<p>{{.Task.Description}}</p>{{end}}
+<h2>Files</h2>
+<ul>
+{{- range .Navigation}}{{if .URL}}
+<li><a href="{{$.Root}}{{.URL}}">{{.Path}}</a></li>
+{{- end}}{{end}}
+</ul>
+<h2>Keywords</h2>
+<ul>
+{{- range .Keywords}}
+<li><a href="{{$.Root}}{{.URL}}">{{.Title}}</a></li>
+{{- end}}
+</ul>
+{{end}}`,
+	"document.html": `{{define "content"}}
+<h1>{{.Title}}</h1>
+{{template "nodes" (branch .Root .Document.Data)}}
+{{end}}
+{{define "nodes"}}<ul class="nodes">
+{{- range .Nodes}}
+<li{{if .ID}} id="{{.ID}}"{{end}}>
+{{- if .Keyword}}<a class="keyword" href="{{$.Root}}keywords/{{.Keyword}}.html">{{.Keyword}}</a>{{end}}
+{{- range .Flags}} <span class="flag">{{.}}</span>{{end}}
+{{- if .Reference}} <a class="ref" href="#{{.Reference}}">{{.Reference}}</a>{{end}}
+{{- if .Value}}{{if .Keyword}}<div class="value">{{.Value}}</div>{{else}}<pre>{{.Value}}</pre>{{end}}{{end}}
+{{- if .Children}}{{template "nodes" (branch $.Root .Children)}}{{end}}
+</li>
+{{- end}}
+</ul>{{end}}`,
+	"keyword.html": `{{define "content"}}
+<h1>{{.Title}}</h1>
+<ul>
+{{- range .Occurrences}}
+<li><a href="{{$.Root}}{{.URL}}">{{.Path}}:{{.Line}}</a> {{.Value}}</li>
+{{- end}}
+</ul>
+{{end}}`,
+	"style.css": `body { display: flex; margin: 0; font: 15px/1.5 -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; color: #24292e; }
+nav { width: 18em; min-height: 100vh; padding: 1em; background: #f6f8fa; border-right: 1px solid #e1e4e8; box-sizing: border-box; }
+nav ul { list-style: none; padding: 0; }
+nav .site { font-weight: bold; display: block; margin-bottom: 1em; }
+nav input { width: 100%; box-sizing: border-box; }
+main { flex: 1; padding: 1em 2em; max-width: 60em; }
+a { color: #0366d6; text-decoration: none; }
+.keyword { font-weight: bold; }
+.flag { font-size: 0.8em; padding: 0 0.4em; border-radius: 0.8em; background: #e1e4e8; }
+.value { white-space: pre-wrap; }
+pre { background: #f6f8fa; padding: 0.5em; overflow: auto; }
+.nodes .nodes { border-left: 2px solid #e1e4e8; padding-left: 1em; }
+`,
+	"search.js": `(function () {
+  var input = document.getElementById("search");
+  var results = document.getElementById("results");
+  if (!input || !results || !window.emitsSearch) {
+    return;
+  }
+  input.addEventListener("input", function () {
+    var query = input.value.toLowerCase().trim();
+    results.innerHTML = "";
+    if (query.length < 2) {
+      return;
+    }
+    window.emitsSearch.filter(function (entry) {
+      return (entry.keyword + " " + entry.value).toLowerCase().indexOf(query) >= 0;
+    }).slice(0, 20).forEach(function (entry) {
+      var item = document.createElement("li");
+      var link = document.createElement("a");
+      link.href = root + entry.url;
+      link.textContent = entry.keyword + " " + entry.value;
+      item.appendChild(link);
+      results.appendChild(item);
+    });
+  });
+})();
+`,
+}